@@ -23,6 +23,7 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PlaylistServiceClient interface {
 	CreateSong(ctx context.Context, in *CreateSongRequest, opts ...grpc.CallOption) (*CreateSongResponse, error)
+	CreateSongFromFile(ctx context.Context, in *CreateSongFromFileRequest, opts ...grpc.CallOption) (*CreateSongResponse, error)
 	GetSong(ctx context.Context, in *ReadSongRequest, opts ...grpc.CallOption) (*ReadSongResponse, error)
 	GetSongs(ctx context.Context, in *ReadSongsRequest, opts ...grpc.CallOption) (*ReadSongsResponse, error)
 	UpdateSong(ctx context.Context, in *UpdateSongRequest, opts ...grpc.CallOption) (*UpdateSongResponse, error)
@@ -32,6 +33,18 @@ type PlaylistServiceClient interface {
 	Next(ctx context.Context, in *NextSongRequest, opts ...grpc.CallOption) (*NextSongResponse, error)
 	Prev(ctx context.Context, in *PrevSongRequest, opts ...grpc.CallOption) (*PrevSongResponse, error)
 	Player(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (PlaylistService_PlayerClient, error)
+	ImportSongs(ctx context.Context, opts ...grpc.CallOption) (PlaylistService_ImportSongsClient, error)
+	BatchCreateSongs(ctx context.Context, in *BatchCreateSongsRequest, opts ...grpc.CallOption) (*BatchCreateSongsResponse, error)
+	BatchDeleteSongs(ctx context.Context, in *BatchDeleteSongsRequest, opts ...grpc.CallOption) (*BatchDeleteSongsResponse, error)
+	ScanLibrary(ctx context.Context, in *ScanLibraryRequest, opts ...grpc.CallOption) (PlaylistService_ScanLibraryClient, error)
+	GetStreamURL(ctx context.Context, in *GetStreamURLRequest, opts ...grpc.CallOption) (*GetStreamURLResponse, error)
+	Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error)
+	GetPlayerState(ctx context.Context, in *GetPlayerStateRequest, opts ...grpc.CallOption) (*PlayerState, error)
+	GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error)
+	MoveSong(ctx context.Context, in *MoveSongRequest, opts ...grpc.CallOption) (*MoveSongResponse, error)
+	SuggestSong(ctx context.Context, in *SuggestSongRequest, opts ...grpc.CallOption) (*PartyQueue, error)
+	VoteSong(ctx context.Context, in *VoteSongRequest, opts ...grpc.CallOption) (*PartyQueue, error)
+	GetPartyQueue(ctx context.Context, in *GetPartyQueueRequest, opts ...grpc.CallOption) (*PartyQueue, error)
 }
 
 type playlistServiceClient struct {
@@ -51,6 +64,15 @@ func (c *playlistServiceClient) CreateSong(ctx context.Context, in *CreateSongRe
 	return out, nil
 }
 
+func (c *playlistServiceClient) CreateSongFromFile(ctx context.Context, in *CreateSongFromFileRequest, opts ...grpc.CallOption) (*CreateSongResponse, error) {
+	out := new(CreateSongResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/CreateSongFromFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *playlistServiceClient) GetSong(ctx context.Context, in *ReadSongRequest, opts ...grpc.CallOption) (*ReadSongResponse, error) {
 	out := new(ReadSongResponse)
 	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/GetSong", in, out, opts...)
@@ -155,11 +177,168 @@ func (x *playlistServicePlayerClient) Recv() (*PlayerInfo, error) {
 	return m, nil
 }
 
+func (c *playlistServiceClient) ImportSongs(ctx context.Context, opts ...grpc.CallOption) (PlaylistService_ImportSongsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaylistService_ServiceDesc.Streams[1], "/playlist_service.PlaylistService/ImportSongs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playlistServiceImportSongsClient{stream}
+	return x, nil
+}
+
+type PlaylistService_ImportSongsClient interface {
+	Send(*ImportSongsRequest) error
+	CloseAndRecv() (*ImportSongsResponse, error)
+	grpc.ClientStream
+}
+
+type playlistServiceImportSongsClient struct {
+	grpc.ClientStream
+}
+
+func (x *playlistServiceImportSongsClient) Send(m *ImportSongsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *playlistServiceImportSongsClient) CloseAndRecv() (*ImportSongsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSongsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playlistServiceClient) BatchCreateSongs(ctx context.Context, in *BatchCreateSongsRequest, opts ...grpc.CallOption) (*BatchCreateSongsResponse, error) {
+	out := new(BatchCreateSongsResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/BatchCreateSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) BatchDeleteSongs(ctx context.Context, in *BatchDeleteSongsRequest, opts ...grpc.CallOption) (*BatchDeleteSongsResponse, error) {
+	out := new(BatchDeleteSongsResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/BatchDeleteSongs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) ScanLibrary(ctx context.Context, in *ScanLibraryRequest, opts ...grpc.CallOption) (PlaylistService_ScanLibraryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PlaylistService_ServiceDesc.Streams[2], "/playlist_service.PlaylistService/ScanLibrary", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &playlistServiceScanLibraryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PlaylistService_ScanLibraryClient interface {
+	Recv() (*ScanProgress, error)
+	grpc.ClientStream
+}
+
+type playlistServiceScanLibraryClient struct {
+	grpc.ClientStream
+}
+
+func (x *playlistServiceScanLibraryClient) Recv() (*ScanProgress, error) {
+	m := new(ScanProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *playlistServiceClient) GetStreamURL(ctx context.Context, in *GetStreamURLRequest, opts ...grpc.CallOption) (*GetStreamURLResponse, error) {
+	out := new(GetStreamURLResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/GetStreamURL", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) Seek(ctx context.Context, in *SeekRequest, opts ...grpc.CallOption) (*SeekResponse, error) {
+	out := new(SeekResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/Seek", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) GetPlayerState(ctx context.Context, in *GetPlayerStateRequest, opts ...grpc.CallOption) (*PlayerState, error) {
+	out := new(PlayerState)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/GetPlayerState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) GetPlaylist(ctx context.Context, in *GetPlaylistRequest, opts ...grpc.CallOption) (*GetPlaylistResponse, error) {
+	out := new(GetPlaylistResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/GetPlaylist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) MoveSong(ctx context.Context, in *MoveSongRequest, opts ...grpc.CallOption) (*MoveSongResponse, error) {
+	out := new(MoveSongResponse)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/MoveSong", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) SuggestSong(ctx context.Context, in *SuggestSongRequest, opts ...grpc.CallOption) (*PartyQueue, error) {
+	out := new(PartyQueue)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/SuggestSong", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) VoteSong(ctx context.Context, in *VoteSongRequest, opts ...grpc.CallOption) (*PartyQueue, error) {
+	out := new(PartyQueue)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/VoteSong", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playlistServiceClient) GetPartyQueue(ctx context.Context, in *GetPartyQueueRequest, opts ...grpc.CallOption) (*PartyQueue, error) {
+	out := new(PartyQueue)
+	err := c.cc.Invoke(ctx, "/playlist_service.PlaylistService/GetPartyQueue", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PlaylistServiceServer is the server API for PlaylistService service.
 // All implementations must embed UnimplementedPlaylistServiceServer
 // for forward compatibility
 type PlaylistServiceServer interface {
 	CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error)
+	CreateSongFromFile(context.Context, *CreateSongFromFileRequest) (*CreateSongResponse, error)
 	GetSong(context.Context, *ReadSongRequest) (*ReadSongResponse, error)
 	GetSongs(context.Context, *ReadSongsRequest) (*ReadSongsResponse, error)
 	UpdateSong(context.Context, *UpdateSongRequest) (*UpdateSongResponse, error)
@@ -169,6 +348,18 @@ type PlaylistServiceServer interface {
 	Next(context.Context, *NextSongRequest) (*NextSongResponse, error)
 	Prev(context.Context, *PrevSongRequest) (*PrevSongResponse, error)
 	Player(*ConnectRequest, PlaylistService_PlayerServer) error
+	ImportSongs(PlaylistService_ImportSongsServer) error
+	BatchCreateSongs(context.Context, *BatchCreateSongsRequest) (*BatchCreateSongsResponse, error)
+	BatchDeleteSongs(context.Context, *BatchDeleteSongsRequest) (*BatchDeleteSongsResponse, error)
+	ScanLibrary(*ScanLibraryRequest, PlaylistService_ScanLibraryServer) error
+	GetStreamURL(context.Context, *GetStreamURLRequest) (*GetStreamURLResponse, error)
+	Seek(context.Context, *SeekRequest) (*SeekResponse, error)
+	GetPlayerState(context.Context, *GetPlayerStateRequest) (*PlayerState, error)
+	GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error)
+	MoveSong(context.Context, *MoveSongRequest) (*MoveSongResponse, error)
+	SuggestSong(context.Context, *SuggestSongRequest) (*PartyQueue, error)
+	VoteSong(context.Context, *VoteSongRequest) (*PartyQueue, error)
+	GetPartyQueue(context.Context, *GetPartyQueueRequest) (*PartyQueue, error)
 	mustEmbedUnimplementedPlaylistServiceServer()
 }
 
@@ -179,6 +370,9 @@ type UnimplementedPlaylistServiceServer struct {
 func (UnimplementedPlaylistServiceServer) CreateSong(context.Context, *CreateSongRequest) (*CreateSongResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSong not implemented")
 }
+func (UnimplementedPlaylistServiceServer) CreateSongFromFile(context.Context, *CreateSongFromFileRequest) (*CreateSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSongFromFile not implemented")
+}
 func (UnimplementedPlaylistServiceServer) GetSong(context.Context, *ReadSongRequest) (*ReadSongResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSong not implemented")
 }
@@ -206,6 +400,42 @@ func (UnimplementedPlaylistServiceServer) Prev(context.Context, *PrevSongRequest
 func (UnimplementedPlaylistServiceServer) Player(*ConnectRequest, PlaylistService_PlayerServer) error {
 	return status.Errorf(codes.Unimplemented, "method Player not implemented")
 }
+func (UnimplementedPlaylistServiceServer) ImportSongs(PlaylistService_ImportSongsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportSongs not implemented")
+}
+func (UnimplementedPlaylistServiceServer) BatchCreateSongs(context.Context, *BatchCreateSongsRequest) (*BatchCreateSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateSongs not implemented")
+}
+func (UnimplementedPlaylistServiceServer) BatchDeleteSongs(context.Context, *BatchDeleteSongsRequest) (*BatchDeleteSongsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteSongs not implemented")
+}
+func (UnimplementedPlaylistServiceServer) ScanLibrary(*ScanLibraryRequest, PlaylistService_ScanLibraryServer) error {
+	return status.Errorf(codes.Unimplemented, "method ScanLibrary not implemented")
+}
+func (UnimplementedPlaylistServiceServer) GetStreamURL(context.Context, *GetStreamURLRequest) (*GetStreamURLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreamURL not implemented")
+}
+func (UnimplementedPlaylistServiceServer) Seek(context.Context, *SeekRequest) (*SeekResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Seek not implemented")
+}
+func (UnimplementedPlaylistServiceServer) GetPlayerState(context.Context, *GetPlayerStateRequest) (*PlayerState, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayerState not implemented")
+}
+func (UnimplementedPlaylistServiceServer) GetPlaylist(context.Context, *GetPlaylistRequest) (*GetPlaylistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlaylist not implemented")
+}
+func (UnimplementedPlaylistServiceServer) MoveSong(context.Context, *MoveSongRequest) (*MoveSongResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveSong not implemented")
+}
+func (UnimplementedPlaylistServiceServer) SuggestSong(context.Context, *SuggestSongRequest) (*PartyQueue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestSong not implemented")
+}
+func (UnimplementedPlaylistServiceServer) VoteSong(context.Context, *VoteSongRequest) (*PartyQueue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteSong not implemented")
+}
+func (UnimplementedPlaylistServiceServer) GetPartyQueue(context.Context, *GetPartyQueueRequest) (*PartyQueue, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPartyQueue not implemented")
+}
 func (UnimplementedPlaylistServiceServer) mustEmbedUnimplementedPlaylistServiceServer() {}
 
 // UnsafePlaylistServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -237,6 +467,24 @@ func _PlaylistService_CreateSong_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PlaylistService_CreateSongFromFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSongFromFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).CreateSongFromFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/CreateSongFromFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).CreateSongFromFile(ctx, req.(*CreateSongFromFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PlaylistService_GetSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReadSongRequest)
 	if err := dec(in); err != nil {
@@ -402,6 +650,233 @@ func (x *playlistServicePlayerServer) Send(m *PlayerInfo) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _PlaylistService_ImportSongs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PlaylistServiceServer).ImportSongs(&playlistServiceImportSongsServer{stream})
+}
+
+type PlaylistService_ImportSongsServer interface {
+	SendAndClose(*ImportSongsResponse) error
+	Recv() (*ImportSongsRequest, error)
+	grpc.ServerStream
+}
+
+type playlistServiceImportSongsServer struct {
+	grpc.ServerStream
+}
+
+func (x *playlistServiceImportSongsServer) SendAndClose(m *ImportSongsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *playlistServiceImportSongsServer) Recv() (*ImportSongsRequest, error) {
+	m := new(ImportSongsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PlaylistService_BatchCreateSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).BatchCreateSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/BatchCreateSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).BatchCreateSongs(ctx, req.(*BatchCreateSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_BatchDeleteSongs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteSongsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).BatchDeleteSongs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/BatchDeleteSongs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).BatchDeleteSongs(ctx, req.(*BatchDeleteSongsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_ScanLibrary_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ScanLibraryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PlaylistServiceServer).ScanLibrary(m, &playlistServiceScanLibraryServer{stream})
+}
+
+type PlaylistService_ScanLibraryServer interface {
+	Send(*ScanProgress) error
+	grpc.ServerStream
+}
+
+type playlistServiceScanLibraryServer struct {
+	grpc.ServerStream
+}
+
+func (x *playlistServiceScanLibraryServer) Send(m *ScanProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _PlaylistService_GetStreamURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).GetStreamURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/GetStreamURL",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).GetStreamURL(ctx, req.(*GetStreamURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_Seek_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeekRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).Seek(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/Seek",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).Seek(ctx, req.(*SeekRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_GetPlayerState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlayerStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).GetPlayerState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/GetPlayerState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).GetPlayerState(ctx, req.(*GetPlayerStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_GetPlaylist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPlaylistRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).GetPlaylist(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/GetPlaylist",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).GetPlaylist(ctx, req.(*GetPlaylistRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_MoveSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).MoveSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/MoveSong",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).MoveSong(ctx, req.(*MoveSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_SuggestSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).SuggestSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/SuggestSong",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).SuggestSong(ctx, req.(*SuggestSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_VoteSong_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteSongRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).VoteSong(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/VoteSong",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).VoteSong(ctx, req.(*VoteSongRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlaylistService_GetPartyQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPartyQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlaylistServiceServer).GetPartyQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/playlist_service.PlaylistService/GetPartyQueue",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlaylistServiceServer).GetPartyQueue(ctx, req.(*GetPartyQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PlaylistService_ServiceDesc is the grpc.ServiceDesc for PlaylistService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -413,6 +888,10 @@ var PlaylistService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateSong",
 			Handler:    _PlaylistService_CreateSong_Handler,
 		},
+		{
+			MethodName: "CreateSongFromFile",
+			Handler:    _PlaylistService_CreateSongFromFile_Handler,
+		},
 		{
 			MethodName: "GetSong",
 			Handler:    _PlaylistService_GetSong_Handler,
@@ -445,6 +924,46 @@ var PlaylistService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Prev",
 			Handler:    _PlaylistService_Prev_Handler,
 		},
+		{
+			MethodName: "BatchCreateSongs",
+			Handler:    _PlaylistService_BatchCreateSongs_Handler,
+		},
+		{
+			MethodName: "BatchDeleteSongs",
+			Handler:    _PlaylistService_BatchDeleteSongs_Handler,
+		},
+		{
+			MethodName: "GetStreamURL",
+			Handler:    _PlaylistService_GetStreamURL_Handler,
+		},
+		{
+			MethodName: "Seek",
+			Handler:    _PlaylistService_Seek_Handler,
+		},
+		{
+			MethodName: "GetPlayerState",
+			Handler:    _PlaylistService_GetPlayerState_Handler,
+		},
+		{
+			MethodName: "GetPlaylist",
+			Handler:    _PlaylistService_GetPlaylist_Handler,
+		},
+		{
+			MethodName: "MoveSong",
+			Handler:    _PlaylistService_MoveSong_Handler,
+		},
+		{
+			MethodName: "SuggestSong",
+			Handler:    _PlaylistService_SuggestSong_Handler,
+		},
+		{
+			MethodName: "VoteSong",
+			Handler:    _PlaylistService_VoteSong_Handler,
+		},
+		{
+			MethodName: "GetPartyQueue",
+			Handler:    _PlaylistService_GetPartyQueue_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -452,6 +971,16 @@ var PlaylistService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _PlaylistService_Player_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ImportSongs",
+			Handler:       _PlaylistService_ImportSongs_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ScanLibrary",
+			Handler:       _PlaylistService_ScanLibrary_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/playlist_service.proto",
 }
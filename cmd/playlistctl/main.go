@@ -0,0 +1,105 @@
+// Command playlistctl is a command-line client for PlaylistService.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"os"
+	"time"
+)
+
+const usage = `Usage: playlistctl [flags] <command> [args]
+
+Commands:
+  songs list                          list all songs
+  songs show <id>                     show one song
+  songs add -title T -duration 3:35   add a song (-artist, -album)
+  songs add -file <path>              add a song from a local audio file
+  songs edit <id> [-title T ...]      change title, duration, artist or album
+  songs rm <id>...                    delete songs
+  songs import [-format csv|ndjson] [-dry-run] <file>
+  songs url <id> [-ttl 5m]            signed stream and HLS URLs of a song
+  playlist                            list songs in playback order
+  move <id> <position>                move a song to a zero-based position in the playlist
+  play | pause | next | prev          control the player
+  seek <position>                     seek within the current song (seconds or m:ss)
+  status                              show the current song
+  watch                               follow the player until interrupted
+  scan                                scan the music directories of the server
+  party                               list the songs suggested in party mode
+  party suggest <id>                  suggest a song to play next
+  party vote <id> up|down|none        vote for a suggested song
+
+Flags:
+`
+
+type app struct {
+	client  ps.PlaylistServiceClient
+	out     output
+	timeout time.Duration
+}
+
+func (a *app) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), a.timeout)
+}
+
+func main() {
+	var conn cli.ConnFlags
+	conn.Register(flag.CommandLine)
+	format := flag.String("o", "table", "output format: table or json")
+	timeout := flag.Duration("timeout", 10*time.Second, "timeout of a single request")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	out, err := newOutput(*format, os.Stdout)
+	if err != nil {
+		fatal(err)
+	}
+	cc, err := conn.Dial()
+	if err != nil {
+		fatal(err)
+	}
+	defer cc.Close()
+	a := &app{client: ps.NewPlaylistServiceClient(cc), out: out, timeout: *timeout}
+	if err = a.run(flag.Args()); err != nil {
+		fatal(err)
+	}
+}
+
+func (a *app) run(args []string) error {
+	switch args[0] {
+	case "songs":
+		return a.songs(args[1:])
+	case "playlist":
+		return a.playlist()
+	case "move":
+		return a.move(args[1:])
+	case "play", "pause", "next", "prev":
+		return a.control(args[0])
+	case "seek":
+		return a.seek(args[1:])
+	case "status":
+		return a.status()
+	case "watch":
+		return a.watch()
+	case "scan":
+		return a.scan()
+	case "party":
+		return a.party(args[1:])
+	}
+	return fmt.Errorf("unknown command %q", args[0])
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "playlistctl:", err)
+	os.Exit(1)
+}
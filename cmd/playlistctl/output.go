@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"text/tabwriter"
+)
+
+type output struct {
+	json bool
+	w    io.Writer
+}
+
+func newOutput(format string, w io.Writer) (output, error) {
+	switch format {
+	case "table":
+		return output{w: w}, nil
+	case "json":
+		return output{json: true, w: w}, nil
+	}
+	return output{}, fmt.Errorf("unknown output format %q", format)
+}
+
+// message prints m as indented JSON, or as a single line when stream is set
+// so that every message of a stream stays on its own line.
+func (o output) message(m proto.Message, stream bool) error {
+	options := protojson.MarshalOptions{EmitUnpopulated: true}
+	if !stream {
+		options.Multiline, options.Indent = true, "  "
+	}
+	body, err := options.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(o.w, string(body))
+	return err
+}
+
+func (o output) songs(songs []*ps.SongInfo) error {
+	if o.json {
+		return o.message(&ps.ReadSongsResponse{Songs: songs}, false)
+	}
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tARTIST\tALBUM\tDURATION\t")
+	for _, song := range songs {
+		title := song.Title
+		if song.Missing {
+			title += " (missing)"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t\n", song.Id, title, song.Artist, song.Album, cli.FormatDuration(song.Duration))
+	}
+	return tw.Flush()
+}
+
+func (o output) song(song *ps.SongInfo) error {
+	if o.json {
+		return o.message(song, false)
+	}
+	tw := tabwriter.NewWriter(o.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", song.Id)
+	fmt.Fprintf(tw, "Title:\t%s\n", song.Title)
+	fmt.Fprintf(tw, "Artist:\t%s\n", song.Artist)
+	fmt.Fprintf(tw, "Album:\t%s\n", song.Album)
+	fmt.Fprintf(tw, "Duration:\t%s\n", cli.FormatDuration(song.Duration))
+	if song.Path != "" {
+		fmt.Fprintf(tw, "Path:\t%s\n", song.Path)
+	}
+	if song.Missing {
+		fmt.Fprintln(tw, "Missing:\tyes")
+	}
+	return tw.Flush()
+}
+
+func (o output) player(info *ps.PlayerInfo) error {
+	if o.json {
+		return o.message(info, true)
+	}
+	switch {
+	case info.Event == "":
+		_, err := fmt.Fprintln(o.w, cli.FormatPlayerInfo(info))
+		return err
+	case info.Song != nil:
+		_, err := fmt.Fprintf(o.w, "[%s] %s\n", info.Event, cli.SongTitle(info.Song))
+		return err
+	}
+	_, err := fmt.Fprintf(o.w, "[%s]\n", info.Event)
+	return err
+}
+
+func (o output) text(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(o.w, format+"\n", args...)
+	return err
+}
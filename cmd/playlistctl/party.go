@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"text/tabwriter"
+)
+
+var votes = map[string]int32{"up": 1, "down": -1, "none": 0}
+
+func (a *app) party(args []string) error {
+	ctx, cancel := a.context()
+	defer cancel()
+	var queue *ps.PartyQueue
+	var err error
+	switch {
+	case len(args) == 0:
+		queue, err = a.client.GetPartyQueue(ctx, &ps.GetPartyQueueRequest{})
+	case args[0] == "suggest" && len(args) == 2:
+		queue, err = a.client.SuggestSong(ctx, &ps.SuggestSongRequest{Id: args[1]})
+	case args[0] == "vote" && len(args) == 3:
+		vote, ok := votes[args[2]]
+		if !ok {
+			return fmt.Errorf("bad vote %q, want up, down or none", args[2])
+		}
+		queue, err = a.client.VoteSong(ctx, &ps.VoteSongRequest{Id: args[1], Vote: vote})
+	default:
+		return errors.New("usage: party [suggest <id> | vote <id> up|down|none]")
+	}
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(queue, false)
+	}
+	tw := tabwriter.NewWriter(a.out.w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORE\tVOTE\tID\tTITLE\tDURATION\tSUGGESTED BY\t")
+	for _, entry := range queue.Entries {
+		vote := ""
+		switch entry.Vote {
+		case 1:
+			vote = "up"
+		case -1:
+			vote = "down"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t\n", entry.Score, vote, entry.Song.GetId(), cli.SongTitle(entry.Song),
+			cli.FormatDuration(entry.Song.GetDuration()), entry.SuggestedBy)
+	}
+	return tw.Flush()
+}
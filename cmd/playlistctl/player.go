@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// contextWithoutTimeout is cancelled by Ctrl+C only, for streams that run
+// until the user stops them.
+func contextWithoutTimeout() context.Context {
+	ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	return ctx
+}
+
+func (a *app) control(command string) error {
+	ctx, cancel := a.context()
+	defer cancel()
+	var err error
+	switch command {
+	case "play":
+		_, err = a.client.Play(ctx, &ps.PlayRequest{})
+	case "pause":
+		_, err = a.client.Pause(ctx, &ps.PauseRequest{})
+	case "next":
+		_, err = a.client.Next(ctx, &ps.NextSongRequest{})
+	case "prev":
+		_, err = a.client.Prev(ctx, &ps.PrevSongRequest{})
+	}
+	if err != nil {
+		return err
+	}
+	return a.status()
+}
+
+func (a *app) seek(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: seek <seconds|m:ss>")
+	}
+	position, err := cli.ParseDuration(args[0])
+	if err != nil {
+		return err
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	if _, err = a.client.Seek(ctx, &ps.SeekRequest{Position: position}); err != nil {
+		return err
+	}
+	return a.status()
+}
+
+func (a *app) status() error {
+	ctx, cancel := a.context()
+	defer cancel()
+	state, err := a.client.GetPlayerState(ctx, &ps.GetPlayerStateRequest{})
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(state, false)
+	}
+	if state.Song == nil {
+		return a.out.text("stopped")
+	}
+	mode := "paused"
+	if state.Playing {
+		mode = "playing"
+	}
+	info := &ps.PlayerInfo{Title: cli.SongTitle(state.Song), Duration: state.Song.Duration, Elapsed: state.Elapsed}
+	return a.out.text("[%s] %s", mode, cli.FormatPlayerInfo(info))
+}
+
+func (a *app) watch() error {
+	stream, err := a.client.Player(contextWithoutTimeout(), &ps.ConnectRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		info, errRecv := stream.Recv()
+		if errors.Is(errRecv, io.EOF) || status.Code(errRecv) == codes.Canceled {
+			return nil
+		}
+		if errRecv != nil {
+			return errRecv
+		}
+		if err = a.out.player(info); err != nil {
+			return err
+		}
+	}
+}
+
+func (a *app) scan() error {
+	stream, err := a.client.ScanLibrary(contextWithoutTimeout(), &ps.ScanLibraryRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		progress, errRecv := stream.Recv()
+		if errors.Is(errRecv, io.EOF) {
+			return nil
+		}
+		if errRecv != nil {
+			return errRecv
+		}
+		switch {
+		case a.out.json:
+			err = a.out.message(progress, true)
+		case progress.Done:
+			err = a.out.text("scanned %d files: %d added, %d updated, %d removed, %d failed",
+				progress.Scanned, progress.Added, progress.Updated, progress.Removed, progress.Failed)
+		case progress.Error != "":
+			err = a.out.text("%s %s: %s", progress.Action, progress.Path, progress.Error)
+		case progress.Action != "unchanged":
+			err = a.out.text("%s %s", progress.Action, progress.Path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
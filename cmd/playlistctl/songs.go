@@ -0,0 +1,282 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+const importChunkSize = 64 * 1024
+
+func (a *app) songs(args []string) error {
+	if len(args) == 0 {
+		return errors.New("songs: expected list, show, add, edit, rm, import or url")
+	}
+	switch args[0] {
+	case "list", "ls":
+		return a.listSongs()
+	case "show":
+		return a.showSong(args[1:])
+	case "add":
+		return a.addSong(args[1:])
+	case "edit":
+		return a.editSong(args[1:])
+	case "rm", "delete":
+		return a.removeSongs(args[1:])
+	case "import":
+		return a.importSongs(args[1:])
+	case "url":
+		return a.songURL(args[1:])
+	}
+	return fmt.Errorf("songs: unknown command %q", args[0])
+}
+
+func (a *app) listSongs() error {
+	ctx, cancel := a.context()
+	defer cancel()
+	res, err := a.client.GetSongs(ctx, &ps.ReadSongsRequest{})
+	if err != nil {
+		return err
+	}
+	return a.out.songs(res.Songs)
+}
+
+func (a *app) playlist() error {
+	ctx, cancel := a.context()
+	defer cancel()
+	res, err := a.client.GetPlaylist(ctx, &ps.GetPlaylistRequest{})
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(res, false)
+	}
+	return a.out.songs(res.Songs)
+}
+
+func (a *app) move(args []string) error {
+	if len(args) != 2 {
+		return errors.New("usage: move <id> <position>")
+	}
+	position, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("bad position %q", args[1])
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	if _, err = a.client.MoveSong(ctx, &ps.MoveSongRequest{Id: args[0], Position: position}); err != nil {
+		return err
+	}
+	return a.playlist()
+}
+
+func (a *app) showSong(args []string) error {
+	if len(args) != 1 {
+		return errors.New("usage: songs show <id>")
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	res, err := a.client.GetSong(ctx, &ps.ReadSongRequest{Id: args[0]})
+	if err != nil {
+		return err
+	}
+	return a.out.song(res.Song)
+}
+
+// songFlags registers the editable fields of a song on fs.
+func songFlags(fs *flag.FlagSet) (title, duration, artist, album *string) {
+	title = fs.String("title", "", "song title")
+	duration = fs.String("duration", "", "duration in seconds or m:ss")
+	artist = fs.String("artist", "", "artist")
+	album = fs.String("album", "", "album")
+	return
+}
+
+func (a *app) addSong(args []string) error {
+	fs := flag.NewFlagSet("songs add", flag.ContinueOnError)
+	title, duration, artist, album := songFlags(fs)
+	file := fs.String("file", "", "create the song from a local audio file instead")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	var res *ps.CreateSongResponse
+	var err error
+	if *file != "" {
+		res, err = a.client.CreateSongFromFile(ctx, &ps.CreateSongFromFileRequest{Path: *file})
+	} else {
+		song := &ps.SongInfo{Title: *title, Artist: *artist, Album: *album}
+		if song.Duration, err = cli.ParseDuration(*duration); err != nil {
+			return err
+		}
+		res, err = a.client.CreateSong(ctx, &ps.CreateSongRequest{Song: song})
+	}
+	if err != nil {
+		return err
+	}
+	return a.out.song(res.Song)
+}
+
+func (a *app) editSong(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: songs edit <id> [-title T] [-duration 3:35] [-artist A] [-album A]")
+	}
+	fs := flag.NewFlagSet("songs edit", flag.ContinueOnError)
+	title, duration, artist, album := songFlags(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	song := &ps.SongInfo{Id: args[0], Title: *title, Artist: *artist, Album: *album}
+	if *duration != "" {
+		seconds, err := cli.ParseDuration(*duration)
+		if err != nil {
+			return err
+		}
+		song.Duration = seconds
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	if _, err := a.client.UpdateSong(ctx, &ps.UpdateSongRequest{Song: song}); err != nil {
+		return err
+	}
+	res, err := a.client.GetSong(ctx, &ps.ReadSongRequest{Id: song.Id})
+	if err != nil {
+		return err
+	}
+	return a.out.song(res.Song)
+}
+
+func (a *app) removeSongs(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: songs rm <id>...")
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	if len(args) == 1 {
+		res, err := a.client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: args[0]})
+		if err != nil {
+			return err
+		}
+		if a.out.json {
+			return a.out.message(res, false)
+		}
+		return a.out.text("deleted %s", args[0])
+	}
+	res, err := a.client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: args})
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(res, false)
+	}
+	failed := 0
+	for i, result := range res.Results {
+		if result.Success {
+			err = a.out.text("deleted %s", args[i])
+		} else {
+			failed++
+			err = a.out.text("failed %s: %s", args[i], result.Error)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d songs were not deleted", failed, len(args))
+	}
+	return nil
+}
+
+func (a *app) importSongs(args []string) error {
+	fs := flag.NewFlagSet("songs import", flag.ContinueOnError)
+	format := fs.String("format", "csv", "input format: csv or ndjson")
+	dryRun := fs.Bool("dry-run", false, "only validate the input")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: songs import [-format csv|ndjson] [-dry-run] <file|->")
+	}
+	var in io.Reader = os.Stdin
+	if fs.Arg(0) != "-" {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	stream, err := a.client.ImportSongs(contextWithoutTimeout())
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, importChunkSize)
+	for {
+		n, errRead := in.Read(buf)
+		if n > 0 {
+			chunk := &ps.ImportSongsRequest{Format: *format, DryRun: *dryRun, Data: append([]byte(nil), buf[:n]...)}
+			if err = stream.Send(chunk); err != nil {
+				return err
+			}
+		}
+		if errRead == io.EOF {
+			break
+		}
+		if errRead != nil {
+			return errRead
+		}
+	}
+	report, err := stream.CloseAndRecv()
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(report, false)
+	}
+	for _, rowErr := range report.Errors {
+		if err = a.out.text("row %d: %s", rowErr.Row, rowErr.Error); err != nil {
+			return err
+		}
+	}
+	verb := "created"
+	if report.DryRun {
+		verb = "would create"
+	}
+	return a.out.text("%d rows: %s %d, %d duplicates, %d invalid", report.Total, verb, report.Created, report.Duplicates, report.Invalid)
+}
+
+func (a *app) songURL(args []string) error {
+	if len(args) == 0 {
+		return errors.New("usage: songs url <id> [-ttl 5m]")
+	}
+	fs := flag.NewFlagSet("songs url", flag.ContinueOnError)
+	ttl := fs.Duration("ttl", 5*time.Minute, "lifetime of the signed URL")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	ctx, cancel := a.context()
+	defer cancel()
+	res, err := a.client.GetStreamURL(ctx, &ps.GetStreamURLRequest{Id: args[0], Ttl: uint64(ttl.Seconds())})
+	if err != nil {
+		return err
+	}
+	if a.out.json {
+		return a.out.message(res, false)
+	}
+	if err = a.out.text("%s", res.Url); err != nil {
+		return err
+	}
+	if res.HlsUrl != "" {
+		if err = a.out.text("%s", res.HlsUrl); err != nil {
+			return err
+		}
+	}
+	return a.out.text("expires %s", time.Unix(res.Expires, 0).Format(time.RFC3339))
+}
@@ -0,0 +1,40 @@
+// Command playlisttui is an interactive terminal client for PlaylistService.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"github.com/sgoldenf/playlist/internal/tui"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	var conn cli.ConnFlags
+	conn.Register(flag.CommandLine)
+	flag.Parse()
+	cc, err := conn.Dial()
+	if err != nil {
+		fatal(err)
+	}
+	defer cc.Close()
+	screen, err := tcell.NewScreen()
+	if err != nil {
+		fatal(err)
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+	if err = tui.New(ps.NewPlaylistServiceClient(cc), screen).Run(ctx); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "playlisttui:", err)
+	os.Exit(1)
+}
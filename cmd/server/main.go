@@ -1,27 +1,320 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"github.com/gin-gonic/gin"
 	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/certs"
+	"github.com/sgoldenf/playlist/internal/httpserver"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/logging"
+	"github.com/sgoldenf/playlist/internal/metrics"
+	"github.com/sgoldenf/playlist/internal/mpd"
+	"github.com/sgoldenf/playlist/internal/party"
 	"github.com/sgoldenf/playlist/internal/server"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"golang.org/x/exp/slog"
 	"google.golang.org/grpc"
-	"log"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 )
 
-var port = flag.Int("port", 50051, "gRPC server port")
+var (
+	port  = flag.Int("port", 50051, "gRPC server port")
+	music = flag.String("music", "", "comma-separated list of music directories")
+	watch = flag.Bool("watch", false, "watch music directories for changes")
+
+	httpPort     = flag.Int("http-port", 8080, "HTTP server port (REST API, streaming)")
+	publicURL    = flag.String("public-url", "", "base URL of the HTTP server used in stream links")
+	streamSecret = flag.String("stream-secret", "", "secret for signing stream URLs (random by default)")
+
+	grpcWebPort = flag.Int("grpc-web-port", 8081, "gRPC-Web server port, 0 disables it")
+	corsOrigins = flag.String("cors-origins", "", "comma-separated list of origins allowed to call gRPC-Web, none by default")
+
+	mpdPort = flag.Int("mpd-port", mpd.DefaultPort, "MPD protocol port, 0 disables it")
+
+	enableReflection = flag.Bool("reflection", false, "enable gRPC server reflection (grpcurl, grpcui)")
+	healthInterval   = flag.Duration("health-interval", 5*time.Second, "how often the health checks run")
+
+	otlpEndpoint     = flag.String("otlp-endpoint", "", "host:port of an OTLP/gRPC collector to send traces to, empty disables tracing")
+	otlpInsecure     = flag.Bool("otlp-insecure", false, "connect to the OTLP collector without TLS")
+	traceSampleRatio = flag.Float64("trace-sample-ratio", 1, "share of new traces that are recorded")
+
+	apiKeys     = flag.String("api-keys", "", "file with API keys, one \"subject key\" pair per line")
+	jwksFile    = flag.String("jwks", "", "JWKS file with the public keys JWTs are verified with")
+	jwtIssuer   = flag.String("jwt-issuer", "", "required iss claim of JWTs")
+	jwtAudience = flag.String("jwt-audience", "", "required aud claim of JWTs")
+	policyFile  = flag.String("policy", "", "JSON file assigning roles to API key subjects and JWT subjects")
+
+	partyMode         = flag.Bool("party", false, "let listeners suggest songs for the shared player and vote on them")
+	partySuggestLimit = flag.Int("party-suggest-limit", 5, "suggestions per user within -party-limit-window, 0 means no limit")
+	partyVoteLimit    = flag.Int("party-vote-limit", 30, "votes per user within -party-limit-window, 0 means no limit")
+	partyLimitWindow  = flag.Duration("party-limit-window", 10*time.Minute, "window of the party rate limits")
+
+	sessions           = flag.Bool("sessions", false, "give every authenticated user a player of their own")
+	sessionIdleTimeout = flag.Duration("session-idle-timeout", server.DefaultSessionIdleTimeout, "time without calls and Player streams after which a session is saved and closed")
+
+	tlsCert              = flag.String("tls-cert", "", "PEM certificate of the gRPC server, enables TLS")
+	tlsKey               = flag.String("tls-key", "", "PEM private key of -tls-cert")
+	tlsClientCA          = flag.String("tls-client-ca", "", "PEM CA certificates to verify client certificates with (mutual TLS)")
+	tlsRequireClientCert = flag.Bool("tls-require-client-cert", false, "reject gRPC clients without a certificate")
+	tlsReloadInterval    = flag.Duration("tls-reload-interval", certs.DefaultReloadInterval, "how often the certificate files are checked for changes")
+
+	logLevel  = flag.String("log-level", "info", "minimum level of log records: debug, info, warn or error")
+	logFormat = flag.String("log-format", "text", "log format: text or json")
+
+	shutdownTimeout = flag.Duration("shutdown-timeout", 10*time.Second, "time to finish requests on SIGINT or SIGTERM before connections are closed")
+)
 
 func main() {
+	flag.Parse()
+	logger, errLogger := logging.New(os.Stderr, *logLevel, *logFormat)
+	if errLogger != nil {
+		fmt.Fprintln(os.Stderr, errLogger)
+		os.Exit(2)
+	}
+	slog.SetDefault(logger)
+	baseURL := *publicURL
+	if baseURL == "" {
+		scheme := "http"
+		if *tlsCert != "" {
+			scheme = "https"
+		}
+		baseURL = fmt.Sprintf("%s://localhost:%d", scheme, *httpPort)
+	}
+	service, errService := server.NewService([]byte(*streamSecret), baseURL)
+	if errService != nil {
+		fatal("failed to serve database", errService)
+	}
+	if *music != "" {
+		service.Scanner.Dirs = strings.Split(*music, ",")
+	}
+	authenticator, err := auth.New(auth.Config{APIKeysFile: *apiKeys, JWKSFile: *jwksFile, Issuer: *jwtIssuer, Audience: *jwtAudience, PolicyFile: *policyFile})
+	if err != nil {
+		fatal("failed to set up authentication", err)
+	}
+	if *tlsClientCA != "" && *tlsCert == "" {
+		fatal("failed to set up authentication", errors.New("-tls-client-ca needs -tls-cert"))
+	}
+	if *tlsRequireClientCert && *tlsClientCA == "" {
+		fatal("failed to set up authentication", errors.New("-tls-require-client-cert needs -tls-client-ca"))
+	}
+	authenticator.ClientCerts = *tlsClientCA != ""
+	if !authenticator.Enabled() {
+		slog.Warn("authentication is disabled, set -api-keys, -jwks or -tls-client-ca to enable it")
+		if *policyFile != "" {
+			slog.Warn("the -policy roles are not checked without authentication")
+		}
+	}
+	service.Auth = authenticator
+	if *partyMode && *sessions {
+		// Party mode picks the songs of the shared player, which the players
+		// of the sessions would leave without any listener.
+		fatal("failed to set up party mode", errors.New("-party can not be combined with -sessions"))
+	}
+	if *partyMode {
+		service.EnableParty(party.NewQueue(
+			party.Limit{Count: *partySuggestLimit, Window: *partyLimitWindow},
+			party.Limit{Count: *partyVoteLimit, Window: *partyLimitWindow},
+		))
+	}
+	if *sessions {
+		if !authenticator.Enabled() {
+			slog.Warn("sessions need authentication, every client shares the player")
+		}
+		service.Sessions = server.NewSessions(*sessionIdleTimeout)
+	}
+	if flag.Arg(0) == "scan" {
+		scan(service)
+		return
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", *port))
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		fatal("failed to listen", err)
 	}
-	s := grpc.NewServer()
-	service, errService := server.NewService()
-	if errService != nil {
-		log.Fatalf("Failed to serve Database: %v", errService)
+	if *watch {
+		watcher := &library.Watcher{Scanner: service.Scanner}
+		go func() {
+			if errWatch := watcher.Run(ctx); errWatch != nil && ctx.Err() == nil {
+				slog.Error("failed to watch library", "err", errWatch)
+			}
+		}()
 	}
+	errs := make(chan error, 4)
+	serve := func(name string, fn func() error) {
+		go func() {
+			if errServe := fn(); errServe != nil && !errors.Is(errServe, http.ErrServerClosed) && !errors.Is(errServe, mpd.ErrServerClosed) {
+				errs <- fmt.Errorf("Failed to serve %s: %w", name, errServe)
+			}
+		}()
+	}
+	stopTracing := func(context.Context) error { return nil }
+	if *otlpEndpoint != "" {
+		stopTracing, err = tracing.Setup(ctx, tracing.Config{Endpoint: *otlpEndpoint, Insecure: *otlpInsecure, SampleRatio: *traceSampleRatio})
+		if err != nil {
+			fatal("failed to set up tracing", err)
+		}
+	}
+	if err = service.DB.Use(tracing.GORM()); err != nil {
+		fatal("failed to set up database tracing", err)
+	}
+	m := metrics.New(service)
+	if err = service.DB.Use(m.GORM()); err != nil {
+		fatal("failed to set up database metrics", err)
+	}
+	gin.SetMode(gin.ReleaseMode)
+	router := httpserver.New(service)
+	router.GET(metrics.Path, gin.WrapH(m.Handler()))
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(otelgrpc.UnaryServerInterceptor(), logging.UnaryInterceptor(logger), auth.UnaryInterceptor(authenticator), m.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(otelgrpc.StreamServerInterceptor(), logging.StreamInterceptor(logger), auth.StreamInterceptor(authenticator), m.StreamInterceptor()),
+	}
+	var reloader *certs.Reloader
+	if *tlsCert != "" {
+		var errCerts error
+		reloader, errCerts = certs.New(certs.Config{CertFile: *tlsCert, KeyFile: *tlsKey, ClientCAFile: *tlsClientCA, RequireClientCert: *tlsRequireClientCert})
+		if errCerts != nil {
+			fatal("failed to load certificates", errCerts)
+		}
+		go reloader.Watch(ctx, *tlsReloadInterval)
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(reloader.ServerConfig())))
+	}
+	httpServer := &http.Server{Addr: fmt.Sprintf(":%d", *httpPort), Handler: router}
+	httpServers := []*http.Server{httpServer}
+	if reloader != nil {
+		httpServer.TLSConfig = reloader.ServerConfig()
+		serve("HTTP", func() error { return httpServer.ListenAndServeTLS("", "") })
+	} else {
+		serve("HTTP", httpServer.ListenAndServe)
+	}
+	s := grpc.NewServer(serverOpts...)
 	ps.RegisterPlaylistServiceServer(s, service)
-	log.Fatalf("Failed to serve: %v", s.Serve(lis))
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	go service.WatchHealth(ctx, healthServer, *healthInterval)
+	if service.Sessions != nil {
+		interval := *sessionIdleTimeout / 4
+		if interval > time.Minute {
+			interval = time.Minute
+		}
+		go service.WatchSessions(ctx, interval)
+	}
+	if *enableReflection {
+		reflection.Register(s)
+	}
+	if *grpcWebPort != 0 {
+		web := &http.Server{Addr: fmt.Sprintf(":%d", *grpcWebPort), Handler: httpserver.GRPCWeb(s, strings.Split(*corsOrigins, ","))}
+		httpServers = append(httpServers, web)
+		if reloader != nil {
+			// The same server as on -port and -http-port, so the same TLS
+			// and client certificate rules apply.
+			web.TLSConfig = reloader.ServerConfig()
+			serve("gRPC-Web", func() error { return web.ListenAndServeTLS("", "") })
+		} else {
+			serve("gRPC-Web", web.ListenAndServe)
+		}
+	}
+	var mpdServer *mpd.Server
+	if *mpdPort != 0 {
+		mpdServer = mpd.New(service)
+		serve("MPD", func() error { return mpdServer.ListenAndServe(fmt.Sprintf(":%d", *mpdPort)) })
+	}
+	serve("gRPC", func() error { return s.Serve(lis) })
+
+	exitCode := 0
+	select {
+	case <-ctx.Done():
+		slog.Info("shutting down")
+	case err = <-errs:
+		slog.Error(err.Error())
+		exitCode = 1
+	}
+	stop()
+	healthServer.Shutdown()
+	shutdown(service, s, httpServers, mpdServer, *shutdownTimeout)
+	flushCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	if err = stopTracing(flushCtx); err != nil {
+		slog.Error("failed to flush traces", "err", err)
+	}
+	cancel()
+	os.Exit(exitCode)
+}
+
+// shutdown ends the Player streams with a final event, pauses the player and
+// saves its state, then stops accepting connections and gives running
+// requests until timeout to finish before the database is closed.
+func shutdown(service *server.PlaylistService, s *grpc.Server, httpServers []*http.Server, mpdServer *mpd.Server, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	service.Shutdown()
+	if err := service.SaveState(ctx); err != nil {
+		slog.Error("failed to save player state", "err", err)
+	}
+	if mpdServer != nil {
+		_ = mpdServer.Close()
+	}
+	var wg sync.WaitGroup
+	for _, srv := range httpServers {
+		wg.Add(1)
+		go func(srv *http.Server) {
+			defer wg.Done()
+			if err := srv.Shutdown(ctx); err != nil {
+				slog.Warn("failed to shut down HTTP server gracefully", "addr", srv.Addr, "err", err)
+				_ = srv.Close()
+			}
+		}(srv)
+	}
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		slog.Warn("failed to stop gRPC server gracefully", "err", ctx.Err())
+		s.Stop()
+	}
+	wg.Wait()
+	if err := service.Close(); err != nil {
+		slog.Error("failed to close database", "err", err)
+	}
+}
+
+func scan(service *server.PlaylistService) {
+	total, err := service.Scanner.Scan(context.Background(), func(progress *ps.ScanProgress) error {
+		if progress.Error != "" {
+			slog.Warn("scan failed", "action", progress.Action, "path", progress.Path, "err", progress.Error)
+		} else if progress.Action != library.ActionUnchanged {
+			slog.Info("scanned", "action", progress.Action, "path", progress.Path)
+		}
+		return nil
+	})
+	if err != nil {
+		fatal("failed to scan library", err)
+	}
+	slog.Info("scan finished", "scanned", total.Scanned, "added", total.Added, "updated", total.Updated,
+		"removed", total.Removed, "failed", total.Failed)
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "err", err)
+	os.Exit(1)
 }
@@ -23,7 +23,7 @@ func New(config PostgresConfig) (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = db.AutoMigrate(ps.SongInfo{})
+	err = db.AutoMigrate(ps.SongInfo{}, PlayerState{}, User{})
 	if err != nil {
 		return nil, err
 	}
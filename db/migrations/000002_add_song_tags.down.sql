@@ -0,0 +1,2 @@
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "album";
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "artist";
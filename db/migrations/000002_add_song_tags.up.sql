@@ -0,0 +1,2 @@
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "artist" TEXT;
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "album" TEXT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS song_infos_path_idx;
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "missing";
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "hash";
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "mtime";
+ALTER TABLE song_infos DROP COLUMN IF EXISTS "path";
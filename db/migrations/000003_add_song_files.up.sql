@@ -0,0 +1,5 @@
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "path" TEXT;
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "mtime" BIGINT;
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "hash" TEXT;
+ALTER TABLE song_infos ADD COLUMN IF NOT EXISTS "missing" BOOLEAN DEFAULT FALSE;
+CREATE INDEX IF NOT EXISTS song_infos_path_idx ON song_infos ("path");
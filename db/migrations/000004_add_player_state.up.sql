@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS "player_states" (
+  "id" BIGINT PRIMARY KEY,
+  "song_id" TEXT,
+  "elapsed" BIGINT,
+  "queue" TEXT
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS "users" (
+  "id" TEXT PRIMARY KEY,
+  "created_at" TIMESTAMPTZ,
+  "last_seen" TIMESTAMPTZ,
+  "song_id" TEXT,
+  "elapsed" BIGINT,
+  "queue" TEXT
+);
@@ -0,0 +1,11 @@
+package postgresdb
+
+// PlayerState is the single row in which the server keeps the player between
+// restarts: the current song, its position and the playlist order.
+type PlayerState struct {
+	ID      int `gorm:"primaryKey"`
+	SongID  string
+	Elapsed uint64
+	// Queue holds the song ids in playlist order, separated by commas.
+	Queue string
+}
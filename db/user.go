@@ -0,0 +1,16 @@
+package postgresdb
+
+import "time"
+
+// User is the account of an authenticated principal. It is created with the
+// first session of the principal and keeps its player between sessions the
+// way PlayerState keeps the shared one.
+type User struct {
+	// ID is the subject of the principal.
+	ID        string `gorm:"primaryKey"`
+	CreatedAt time.Time
+	LastSeen  time.Time
+	SongID    string
+	Elapsed   uint64
+	Queue     string
+}
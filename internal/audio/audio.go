@@ -0,0 +1,121 @@
+// Package audio reads the headers of local audio files to find out their exact
+// duration and basic tags without decoding any audio data.
+package audio
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type Format string
+
+const (
+	MP3    Format = "mp3"
+	FLAC   Format = "flac"
+	Vorbis Format = "vorbis"
+	Opus   Format = "opus"
+	WAV    Format = "wav"
+	AAC    Format = "aac"
+)
+
+var (
+	ErrUnknownFormat = errors.New("audio: unknown format")
+	ErrMalformed     = errors.New("audio: malformed header")
+)
+
+type Metadata struct {
+	Format     Format
+	Duration   time.Duration
+	SampleRate uint32
+	Channels   uint8
+	Title      string
+	Artist     string
+	Album      string
+}
+
+func ReadFile(path string) (*Metadata, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	meta, err := Read(f, stat.Size())
+	if err != nil {
+		return nil, err
+	}
+	if meta.Title == "" {
+		meta.Title = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return meta, nil
+}
+
+func Read(r io.ReaderAt, size int64) (*Metadata, error) {
+	head := make([]byte, 12)
+	n, err := r.ReadAt(head, 0)
+	if n < len(head) {
+		if err == nil || errors.Is(err, io.EOF) {
+			err = ErrUnknownFormat
+		}
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(head, []byte("OggS")):
+		return readOgg(r, size)
+	case bytes.HasPrefix(head, []byte("RIFF")) && bytes.Equal(head[8:12], []byte("WAVE")):
+		return readWAV(r, size)
+	case bytes.HasPrefix(head, []byte("fLaC")):
+		return readFLAC(r, 0, nil)
+	}
+	tag, offset, err := readID3v2(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	if offset > 0 {
+		magic := make([]byte, 4)
+		if _, err = r.ReadAt(magic, offset); err == nil && bytes.Equal(magic, []byte("fLaC")) {
+			return readFLAC(r, offset, tag)
+		}
+	}
+	header := make([]byte, 7)
+	if _, err = r.ReadAt(header, offset); err == nil {
+		if _, ok := parseADTSHeader(header); ok {
+			return readAAC(r, size, offset, tag)
+		}
+	}
+	return readMP3(r, size, offset, tag)
+}
+
+func samplesDuration(samples uint64, rate uint32) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+	r := uint64(rate)
+	return time.Duration(samples/r)*time.Second + time.Duration(samples%r)*time.Second/time.Duration(r)
+}
+
+func (m *Metadata) setTags(t tags) {
+	if m.Title == "" {
+		m.Title = t.title
+	}
+	if m.Artist == "" {
+		m.Artist = t.artist
+	}
+	if m.Album == "" {
+		m.Album = t.album
+	}
+}
+
+type tags struct {
+	title  string
+	artist string
+	album  string
+}
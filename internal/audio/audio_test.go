@@ -0,0 +1,387 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func id3v2Tag(frames map[string]string) []byte {
+	var body bytes.Buffer
+	for _, id := range []string{"TIT2", "TPE1", "TALB"} {
+		text, ok := frames[id]
+		if !ok {
+			continue
+		}
+		data := append([]byte{3}, text...)
+		body.WriteString(id)
+		size := len(data)
+		body.Write([]byte{byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)})
+		body.Write([]byte{0, 0})
+		body.Write(data)
+	}
+	size := body.Len()
+	header := []byte{'I', 'D', '3', 4, 0, 0,
+		byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}
+	return append(header, body.Bytes()...)
+}
+
+func id3v1Tag(title, artist, album string) []byte {
+	b := make([]byte, 128)
+	copy(b, "TAG")
+	copy(b[3:33], title)
+	copy(b[33:63], artist)
+	copy(b[63:93], album)
+	return b
+}
+
+// mpeg1Layer3Frame returns a 128 kbit/s 44.1 kHz stereo frame (417 bytes).
+func mpeg1Layer3Frame() []byte {
+	frame := make([]byte, 417)
+	copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+	return frame
+}
+
+func vorbisComment(comments ...string) []byte {
+	var b bytes.Buffer
+	le := func(n int) { _ = binary.Write(&b, binary.LittleEndian, uint32(n)) }
+	le(len("test vendor"))
+	b.WriteString("test vendor")
+	le(len(comments))
+	for _, c := range comments {
+		le(len(c))
+		b.WriteString(c)
+	}
+	return b.Bytes()
+}
+
+func oggPageBytes(serial uint32, seq uint32, granule int64, packets ...[]byte) []byte {
+	var segments []byte
+	var data []byte
+	for _, p := range packets {
+		n := len(p)
+		for n >= 255 {
+			segments = append(segments, 255)
+			n -= 255
+		}
+		segments = append(segments, byte(n))
+		data = append(data, p...)
+	}
+	header := make([]byte, oggHeaderSize)
+	copy(header, "OggS")
+	binary.LittleEndian.PutUint64(header[6:], uint64(granule))
+	binary.LittleEndian.PutUint32(header[14:], serial)
+	binary.LittleEndian.PutUint32(header[18:], seq)
+	header[26] = byte(len(segments))
+	return append(append(header, segments...), data...)
+}
+
+func TestRead_MP3CBR(t *testing.T) {
+	var b bytes.Buffer
+	b.Write(id3v2Tag(map[string]string{"TIT2": "Take Five", "TPE1": "Dave Brubeck Quartet"}))
+	for i := 0; i < 100; i++ {
+		b.Write(mpeg1Layer3Frame())
+	}
+	b.Write(id3v1Tag("ignored", "ignored", "Time Out"))
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Metadata{
+		Format:     MP3,
+		Duration:   samplesDuration(100*1152, 44100),
+		SampleRate: 44100,
+		Channels:   2,
+		Title:      "Take Five",
+		Artist:     "Dave Brubeck Quartet",
+		Album:      "Time Out",
+	}
+	if *meta != *expected {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected, meta)
+	}
+}
+
+func TestRead_MP3Xing(t *testing.T) {
+	xing := mpeg1Layer3Frame()
+	at := 4 + 32
+	copy(xing[at:], "Xing")
+	binary.BigEndian.PutUint32(xing[at+4:], 1)
+	binary.BigEndian.PutUint32(xing[at+8:], 1000)
+	lame := xing[at+12:]
+	copy(lame, "LAME3.100")
+	lame[21], lame[22], lame[23] = 0x24, 0x00, 0x10
+	var b bytes.Buffer
+	b.Write(xing)
+	for i := 0; i < 10; i++ {
+		b.Write(mpeg1Layer3Frame())
+	}
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := samplesDuration(1000*1152-576-16, 44100); meta.Duration != want {
+		t.Errorf("duration is %v, expected %v", meta.Duration, want)
+	}
+}
+
+func TestRead_FLAC(t *testing.T) {
+	info := make([]byte, 34)
+	const samples = 44100*185 + 22050
+	packed := uint64(44100)<<44 | uint64(1)<<41 | uint64(15)<<36 | samples
+	binary.BigEndian.PutUint64(info[10:], packed)
+	comment := vorbisComment("title=Blue Rondo A La Turk", "ARTIST=Dave Brubeck Quartet", "ALBUM=Time Out")
+	var b bytes.Buffer
+	b.WriteString("fLaC")
+	b.Write([]byte{flacStreamInfo, 0, 0, 34})
+	b.Write(info)
+	b.Write([]byte{0x80 | flacVorbisComment, 0, byte(len(comment) >> 8), byte(len(comment))})
+	b.Write(comment)
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Metadata{
+		Format:     FLAC,
+		Duration:   185*time.Second + 500*time.Millisecond,
+		SampleRate: 44100,
+		Channels:   2,
+		Title:      "Blue Rondo A La Turk",
+		Artist:     "Dave Brubeck Quartet",
+		Album:      "Time Out",
+	}
+	if *meta != *expected {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected, meta)
+	}
+}
+
+func TestRead_OggVorbis(t *testing.T) {
+	ident := make([]byte, 30)
+	copy(ident, "\x01vorbis")
+	ident[11] = 2
+	binary.LittleEndian.PutUint32(ident[12:], 48000)
+	comment := append([]byte("\x03vorbis"), vorbisComment("TITLE=So What", "ARTIST=Miles Davis")...)
+	comment = append(comment, make([]byte, 600)...)
+	var b bytes.Buffer
+	b.Write(oggPageBytes(7, 0, 0, ident))
+	b.Write(oggPageBytes(7, 1, 0, comment))
+	b.Write(oggPageBytes(7, 2, 48000*60, make([]byte, 100)))
+	b.Write(oggPageBytes(7, 3, 48000*90, make([]byte, 100)))
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Metadata{
+		Format:     Vorbis,
+		Duration:   90 * time.Second,
+		SampleRate: 48000,
+		Channels:   2,
+		Title:      "So What",
+		Artist:     "Miles Davis",
+	}
+	if *meta != *expected {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected, meta)
+	}
+}
+
+func TestRead_Opus(t *testing.T) {
+	ident := make([]byte, 19)
+	copy(ident, "OpusHead")
+	ident[8], ident[9] = 1, 1
+	binary.LittleEndian.PutUint16(ident[10:], 312)
+	comment := append([]byte("OpusTags"), vorbisComment("ALBUM=Kind Of Blue")...)
+	var b bytes.Buffer
+	b.Write(oggPageBytes(1, 0, 0, ident))
+	b.Write(oggPageBytes(1, 1, 0, comment))
+	b.Write(oggPageBytes(1, 2, 48000*10+312, make([]byte, 10)))
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.Format != Opus || meta.Duration != 10*time.Second || meta.Channels != 1 || meta.Album != "Kind Of Blue" {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+}
+
+func TestReadFile_WAV(t *testing.T) {
+	var b bytes.Buffer
+	le16 := func(n int) { _ = binary.Write(&b, binary.LittleEndian, uint16(n)) }
+	le32 := func(n int) { _ = binary.Write(&b, binary.LittleEndian, uint32(n)) }
+	info := []byte("INFOIART\x0c\x00\x00\x00Bill Evans\x00\x00")
+	data := 8000 * 2 * 3
+	b.WriteString("RIFF")
+	le32(4 + 8 + 16 + 8 + len(info) + 8 + data)
+	b.WriteString("WAVEfmt ")
+	le32(16)
+	le16(1)
+	le16(1)
+	le32(8000)
+	le32(16000)
+	le16(2)
+	le16(16)
+	b.WriteString("LIST")
+	le32(len(info))
+	b.Write(info)
+	b.WriteString("data")
+	le32(data)
+	b.Write(make([]byte, data))
+	path := filepath.Join(t.TempDir(), "Waltz For Debby.wav")
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	meta, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Metadata{
+		Format:     WAV,
+		Duration:   3 * time.Second,
+		SampleRate: 8000,
+		Channels:   1,
+		Title:      "Waltz For Debby",
+		Artist:     "Bill Evans",
+	}
+	if *meta != *expected {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected, meta)
+	}
+}
+
+// sparseReader serves data followed by zeros up to size and records the
+// largest read.
+type sparseReader struct {
+	data    []byte
+	size    int64
+	largest int
+}
+
+func (r *sparseReader) ReadAt(p []byte, off int64) (int, error) {
+	if len(p) > r.largest {
+		r.largest = len(p)
+	}
+	if off+int64(len(p)) > r.size {
+		return 0, io.EOF
+	}
+	n := 0
+	if off < int64(len(r.data)) {
+		n = copy(p, r.data[off:])
+	}
+	for i := n; i < len(p); i++ {
+		p[i] = 0
+	}
+	return len(p), nil
+}
+
+func TestReadID3v2_Large(t *testing.T) {
+	tag := id3v2Tag(map[string]string{"TIT2": "Take Five"})
+	copy(tag[6:10], []byte{0x7f, 0x7f, 0x7f, 0x7f})
+	r := &sparseReader{data: tag, size: 1 << 30}
+	tags, end, err := readID3v2(r, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tags == nil || tags.title != "Take Five" || end != 10+1<<28-1 {
+		t.Errorf("Out -> \nWant: %q %d\nGot : %+v %d", "Take Five", 10+1<<28-1, tags, end)
+	}
+	if r.largest > maxID3Read {
+		t.Errorf("read %d bytes of the tag, the limit is %d", r.largest, maxID3Read)
+	}
+}
+
+func TestRead_Unknown(t *testing.T) {
+	b := []byte("definitely not an audio file")
+	if _, err := Read(bytes.NewReader(b), int64(len(b))); err != ErrUnknownFormat {
+		t.Errorf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+func TestReadMP3Layout(t *testing.T) {
+	var b bytes.Buffer
+	tag := id3v2Tag(map[string]string{"TIT2": "Take Five"})
+	b.Write(tag)
+	for i := 0; i < 100; i++ {
+		b.Write(mpeg1Layer3Frame())
+	}
+	b.Write(id3v1Tag("Take Five", "", ""))
+	r := bytes.NewReader(b.Bytes())
+	layout, err := ReadMP3Layout(r, int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	start, end := int64(len(tag)), int64(len(tag)+100*417)
+	if layout.Start != start || layout.End != end {
+		t.Errorf("layout is %+v, expected {%d %d}", layout, start, end)
+	}
+	if at := layout.Offset(r, 0, 10*time.Second); at != start {
+		t.Errorf("offset at 0 is %d, expected %d", at, start)
+	}
+	if at := layout.Offset(r, 5*time.Second, 10*time.Second); at != start+50*417 {
+		t.Errorf("offset at 5s is %d, expected %d", at, start+50*417)
+	}
+	if at := layout.Offset(r, 5*time.Second+time.Millisecond, 10*time.Second); at != start+51*417 {
+		t.Errorf("offset after 5s is %d, expected next frame %d", at, start+51*417)
+	}
+	if at := layout.Offset(r, 20*time.Second, 10*time.Second); at != end {
+		t.Errorf("offset past the end is %d, expected %d", at, end)
+	}
+}
+
+// adtsFrame returns an AAC LC 44.1 kHz stereo frame of the given size.
+func adtsFrame(size int) []byte {
+	frame := make([]byte, size)
+	copy(frame, []byte{0xff, 0xf1, 0x50, 0x80 | byte(size>>11), byte(size >> 3), byte(size<<5) | 0x1f, 0xfc})
+	return frame
+}
+
+func TestRead_AAC(t *testing.T) {
+	var b bytes.Buffer
+	b.Write(id3v2Tag(map[string]string{"TIT2": "Autumn Leaves"}))
+	for i := 0; i < 431; i++ {
+		b.Write(adtsFrame(300))
+	}
+	meta, err := Read(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &Metadata{
+		Format:     AAC,
+		Duration:   samplesDuration(431*1024, 44100),
+		SampleRate: 44100,
+		Channels:   2,
+		Title:      "Autumn Leaves",
+	}
+	if *meta != *expected {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected, meta)
+	}
+}
+
+func TestFrames(t *testing.T) {
+	var b bytes.Buffer
+	b.Write(mpeg1Layer3Frame())
+	b.Write(adtsFrame(100))
+	b.Write(mpeg1Layer3Frame())
+	b.WriteString("garbage")
+	var frames []Frame
+	err := Frames(bytes.NewReader(b.Bytes()), 0, int64(b.Len()), func(f Frame) error {
+		frames = append(frames, f)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []Frame{
+		{Offset: 0, Size: 417, Samples: 1152, SampleRate: 44100},
+		{Offset: 417, Size: 100, Samples: 1024, SampleRate: 44100},
+		{Offset: 517, Size: 417, Samples: 1152, SampleRate: 44100},
+	}
+	if len(frames) != len(expected) {
+		t.Fatalf("Out -> \nWant: %+v\nGot : %+v", expected, frames)
+	}
+	for i := range expected {
+		if frames[i] != expected[i] {
+			t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected[i], frames[i])
+		}
+	}
+}
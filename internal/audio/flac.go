@@ -0,0 +1,104 @@
+package audio
+
+import (
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+const (
+	flacStreamInfo    = 0
+	flacVorbisComment = 4
+)
+
+func readFLAC(r io.ReaderAt, offset int64, id3 *tags) (*Metadata, error) {
+	meta := &Metadata{Format: FLAC}
+	pos := offset + 4
+	header := make([]byte, 4)
+	seenInfo := false
+	for {
+		if _, err := r.ReadAt(header, pos); err != nil {
+			return nil, ErrMalformed
+		}
+		last := header[0]&0x80 != 0
+		kind := header[0] & 0x7f
+		length := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+		pos += 4
+		switch kind {
+		case flacStreamInfo:
+			if length < 34 {
+				return nil, ErrMalformed
+			}
+			info := make([]byte, 34)
+			if _, err := r.ReadAt(info, pos); err != nil {
+				return nil, ErrMalformed
+			}
+			packed := binary.BigEndian.Uint64(info[10:18])
+			meta.SampleRate = uint32(packed >> 44)
+			meta.Channels = uint8(packed>>41&0x7) + 1
+			samples := packed & (1<<36 - 1)
+			meta.Duration = samplesDuration(samples, meta.SampleRate)
+			seenInfo = true
+		case flacVorbisComment:
+			block := make([]byte, length)
+			if _, err := r.ReadAt(block, pos); err != nil {
+				return nil, ErrMalformed
+			}
+			meta.setTags(parseVorbisComment(block))
+		}
+		pos += length
+		if last {
+			break
+		}
+	}
+	if !seenInfo {
+		return nil, ErrMalformed
+	}
+	if id3 != nil {
+		meta.setTags(*id3)
+	}
+	return meta, nil
+}
+
+// parseVorbisComment decodes the comment structure shared by FLAC, Ogg Vorbis
+// and Opus: a vendor string followed by a list of KEY=value pairs.
+func parseVorbisComment(b []byte) tags {
+	var t tags
+	if len(b) < 4 {
+		return t
+	}
+	vendor := int(binary.LittleEndian.Uint32(b))
+	if vendor < 0 || 4+vendor+4 > len(b) {
+		return t
+	}
+	b = b[4+vendor:]
+	count := binary.LittleEndian.Uint32(b)
+	b = b[4:]
+	for i := uint32(0); i < count && len(b) >= 4; i++ {
+		n := int(binary.LittleEndian.Uint32(b))
+		if n < 0 || n > len(b)-4 {
+			break
+		}
+		comment := string(b[4 : 4+n])
+		b = b[4+n:]
+		key, value, ok := strings.Cut(comment, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToUpper(key) {
+		case "TITLE":
+			if t.title == "" {
+				t.title = strings.TrimSpace(value)
+			}
+		case "ARTIST":
+			if t.artist == "" {
+				t.artist = strings.TrimSpace(value)
+			}
+		case "ALBUM":
+			if t.album == "" {
+				t.album = strings.TrimSpace(value)
+			}
+		}
+	}
+	return t
+}
@@ -0,0 +1,115 @@
+package audio
+
+import (
+	"bufio"
+	"io"
+)
+
+var adtsSampleRates = [16]uint32{96000, 88200, 64000, 48000, 44100, 32000, 24000, 22050, 16000, 12000, 11025, 8000, 7350}
+
+// Frame is a single MPEG audio or AAC ADTS frame of a file.
+type Frame struct {
+	Offset     int64
+	Size       int
+	Samples    uint32
+	SampleRate uint32
+}
+
+func parseADTSHeader(b []byte) (Frame, bool) {
+	var f Frame
+	if len(b) < 7 || b[0] != 0xff || b[1]&0xf6 != 0xf0 {
+		return f, false
+	}
+	rateIndex := (b[2] >> 2) & 0x0f
+	f.SampleRate = adtsSampleRates[rateIndex]
+	f.Size = int(b[3]&0x03)<<11 | int(b[4])<<3 | int(b[5]>>5)
+	f.Samples = 1024 * (uint32(b[6]&0x03) + 1)
+	if f.SampleRate == 0 || f.Size < 7 {
+		return f, false
+	}
+	return f, true
+}
+
+func parseFrameHeader(b []byte) (Frame, bool) {
+	if f, ok := parseMPEGHeader(b); ok {
+		return Frame{Size: f.size, Samples: f.samples, SampleRate: f.sampleRate}, true
+	}
+	return parseADTSHeader(b)
+}
+
+// Frames calls fn for each consecutive MPEG audio or ADTS frame found between
+// start and end and stops at the first byte that is not a frame header.
+func Frames(r io.ReaderAt, start, end int64, fn func(Frame) error) error {
+	br := bufio.NewReaderSize(io.NewSectionReader(r, start, end-start), 64*1024)
+	offset := start
+	for {
+		header, err := br.Peek(7)
+		if len(header) < 4 {
+			return nil
+		}
+		f, ok := parseFrameHeader(header)
+		if !ok || offset+int64(f.Size) > end {
+			return nil
+		}
+		f.Offset = offset
+		if err = fn(f); err != nil {
+			return err
+		}
+		if _, err = br.Discard(f.Size); err != nil {
+			return nil
+		}
+		offset += int64(f.Size)
+	}
+}
+
+// FrameLayout returns the byte range holding the frames of an MP3 or ADTS
+// file, skipping ID3 tags around it.
+func FrameLayout(r io.ReaderAt, size int64) (MP3Layout, error) {
+	_, offset, err := readID3v2(r, 0)
+	if err != nil {
+		return MP3Layout{}, err
+	}
+	header := make([]byte, 7)
+	if _, err = r.ReadAt(header, offset); err == nil {
+		if _, ok := parseADTSHeader(header); ok {
+			end := size
+			if readID3v1(r, size) != nil {
+				end -= 128
+			}
+			return MP3Layout{Start: offset, End: end}, nil
+		}
+	}
+	return ReadMP3Layout(r, size)
+}
+
+func readAAC(r io.ReaderAt, size, offset int64, id3 *tags) (*Metadata, error) {
+	meta := &Metadata{Format: AAC}
+	if id3 != nil {
+		meta.setTags(*id3)
+	}
+	end := size
+	if v1 := readID3v1(r, size); v1 != nil {
+		meta.setTags(*v1)
+		end -= 128
+	}
+	var samples uint64
+	err := Frames(r, offset, end, func(f Frame) error {
+		if meta.SampleRate == 0 {
+			meta.SampleRate = f.SampleRate
+		}
+		samples += uint64(f.Samples)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if meta.SampleRate == 0 {
+		return nil, ErrUnknownFormat
+	}
+	header := make([]byte, 7)
+	if _, err = r.ReadAt(header, offset); err == nil {
+		meta.Channels = (header[2]&0x01)<<2 | header[3]>>6
+	}
+	meta.Duration = samplesDuration(samples, meta.SampleRate)
+	return meta, nil
+}
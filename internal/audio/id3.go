@@ -0,0 +1,186 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxID3Read bounds the part of an ID3v2 tag that is read, the header may
+// announce up to 256 MiB. Text frames come before pictures in practice, the
+// rest of a larger tag is skipped.
+const maxID3Read = 4 << 20
+
+func syncsafe(b []byte) int64 {
+	var n int64
+	for _, c := range b {
+		n = n<<7 | int64(c&0x7f)
+	}
+	return n
+}
+
+func unsynchronise(b []byte) []byte {
+	return bytes.ReplaceAll(b, []byte{0xff, 0x00}, []byte{0xff})
+}
+
+// readID3v2 parses an ID3v2 tag at offset and returns the offset right after
+// it. A missing tag is not an error: the returned offset equals the input one.
+func readID3v2(r io.ReaderAt, offset int64) (*tags, int64, error) {
+	header := make([]byte, 10)
+	if _, err := r.ReadAt(header, offset); err != nil || !bytes.HasPrefix(header, []byte("ID3")) {
+		return nil, offset, nil
+	}
+	version, flags := header[3], header[5]
+	size := syncsafe(header[6:10])
+	end := offset + 10 + size
+	if flags&0x10 != 0 {
+		end += 10
+	}
+	if version < 2 || version > 4 {
+		return nil, end, nil
+	}
+	n := size
+	if n > maxID3Read {
+		n = maxID3Read
+	}
+	data := make([]byte, n)
+	if _, err := r.ReadAt(data, offset+10); err != nil {
+		return nil, 0, ErrMalformed
+	}
+	if flags&0x80 != 0 && version < 4 {
+		data = unsynchronise(data)
+	}
+	if flags&0x40 != 0 && version > 2 && len(data) >= 4 {
+		ext := int64(binary.BigEndian.Uint32(data[:4])) + 4
+		if version == 4 {
+			ext = syncsafe(data[:4])
+		}
+		if ext > int64(len(data)) {
+			return nil, end, nil
+		}
+		data = data[ext:]
+	}
+	t := parseID3v2Frames(data, version)
+	return &t, end, nil
+}
+
+func parseID3v2Frames(data []byte, version byte) tags {
+	var t tags
+	idLen, headLen := 4, 10
+	if version == 2 {
+		idLen, headLen = 3, 6
+	}
+	for len(data) >= headLen && data[0] != 0 {
+		id := string(data[:idLen])
+		var size int
+		var flags uint16
+		switch version {
+		case 2:
+			size = int(data[3])<<16 | int(data[4])<<8 | int(data[5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(data[4:8]))
+			flags = binary.BigEndian.Uint16(data[8:10])
+		default:
+			size = int(syncsafe(data[4:8]))
+			flags = binary.BigEndian.Uint16(data[8:10])
+		}
+		if size < 0 || size > len(data)-headLen {
+			break
+		}
+		body := data[headLen : headLen+size]
+		data = data[headLen+size:]
+		if version == 3 && flags&0x00c0 != 0 || version == 4 && flags&0x000c != 0 {
+			continue
+		}
+		if version == 4 {
+			if flags&0x0001 != 0 && len(body) >= 4 {
+				body = body[4:]
+			}
+			if flags&0x0002 != 0 {
+				body = unsynchronise(body)
+			}
+		}
+		switch id {
+		case "TIT2", "TT2":
+			t.title = decodeID3Text(body)
+		case "TPE1", "TP1":
+			t.artist = decodeID3Text(body)
+		case "TALB", "TAL":
+			t.album = decodeID3Text(body)
+		}
+	}
+	return t
+}
+
+func decodeID3Text(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	enc, b := b[0], b[1:]
+	var s string
+	switch enc {
+	case 0:
+		s = latin1(b)
+	case 1, 2:
+		s = decodeUTF16(b, enc == 2)
+	default:
+		s = string(b)
+	}
+	if i := strings.IndexByte(s, 0); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func decodeUTF16(b []byte, bigEndian bool) string {
+	if len(b) >= 2 {
+		switch {
+		case b[0] == 0xfe && b[1] == 0xff:
+			bigEndian, b = true, b[2:]
+		case b[0] == 0xff && b[1] == 0xfe:
+			bigEndian, b = false, b[2:]
+		}
+	}
+	u := make([]uint16, 0, len(b)/2)
+	for i := 0; i+1 < len(b); i += 2 {
+		var c uint16
+		if bigEndian {
+			c = binary.BigEndian.Uint16(b[i:])
+		} else {
+			c = binary.LittleEndian.Uint16(b[i:])
+		}
+		if c == 0 {
+			break
+		}
+		u = append(u, c)
+	}
+	return string(utf16.Decode(u))
+}
+
+func latin1(b []byte) string {
+	r := make([]rune, len(b))
+	for i, c := range b {
+		r[i] = rune(c)
+	}
+	return string(r)
+}
+
+// readID3v1 returns the tags stored in the last 128 bytes of the file, if any.
+func readID3v1(r io.ReaderAt, size int64) *tags {
+	if size < 128 {
+		return nil
+	}
+	b := make([]byte, 128)
+	if _, err := r.ReadAt(b, size-128); err != nil || !bytes.HasPrefix(b, []byte("TAG")) {
+		return nil
+	}
+	field := func(f []byte) string {
+		if i := bytes.IndexByte(f, 0); i >= 0 {
+			f = f[:i]
+		}
+		return strings.TrimSpace(latin1(f))
+	}
+	return &tags{title: field(b[3:33]), artist: field(b[33:63]), album: field(b[63:93])}
+}
@@ -0,0 +1,260 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"time"
+)
+
+const (
+	mpeg25 = 0
+	mpeg2  = 2
+	mpeg1  = 3
+)
+
+var mpegBitrates = [2][3][16]uint32{
+	{
+		{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+	},
+	{
+		{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+		{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	},
+}
+
+var mpegSampleRates = [4][3]uint32{
+	mpeg25: {11025, 12000, 8000},
+	mpeg2:  {22050, 24000, 16000},
+	mpeg1:  {44100, 48000, 32000},
+}
+
+type mpegFrame struct {
+	version    int
+	layer      int
+	sampleRate uint32
+	bitrate    uint32
+	channels   uint8
+	samples    uint32
+	size       int
+}
+
+func parseMPEGHeader(b []byte) (mpegFrame, bool) {
+	var f mpegFrame
+	if len(b) < 4 || b[0] != 0xff || b[1]&0xe0 != 0xe0 {
+		return f, false
+	}
+	f.version = int(b[1]>>3) & 3
+	layerBits := int(b[1]>>1) & 3
+	bitrateIndex := b[2] >> 4
+	rateIndex := (b[2] >> 2) & 3
+	if f.version == 1 || layerBits == 0 || bitrateIndex == 0 || bitrateIndex == 15 || rateIndex == 3 {
+		return f, false
+	}
+	f.layer = 4 - layerBits
+	table := 0
+	if f.version != mpeg1 {
+		table = 1
+	}
+	f.bitrate = mpegBitrates[table][f.layer-1][bitrateIndex] * 1000
+	f.sampleRate = mpegSampleRates[f.version][rateIndex]
+	padding := int(b[2]>>1) & 1
+	f.channels = 2
+	if b[3]>>6 == 3 {
+		f.channels = 1
+	}
+	switch {
+	case f.layer == 1:
+		f.samples = 384
+		f.size = (int(12*f.bitrate/f.sampleRate) + padding) * 4
+	case f.layer == 3 && f.version != mpeg1:
+		f.samples = 576
+		f.size = int(72*f.bitrate/f.sampleRate) + padding
+	default:
+		f.samples = 1152
+		f.size = int(144*f.bitrate/f.sampleRate) + padding
+	}
+	return f, true
+}
+
+func (f mpegFrame) sideInfoSize() int {
+	switch {
+	case f.version == mpeg1 && f.channels == 1:
+		return 17
+	case f.version == mpeg1:
+		return 32
+	case f.channels == 1:
+		return 9
+	}
+	return 17
+}
+
+func readMP3(r io.ReaderAt, size, offset int64, id3 *tags) (*Metadata, error) {
+	end := size
+	v1 := readID3v1(r, size)
+	if v1 != nil {
+		end -= 128
+	}
+	start, first, err := findFirstFrame(r, offset, end)
+	if err != nil {
+		return nil, err
+	}
+	meta := &Metadata{Format: MP3, SampleRate: first.sampleRate, Channels: first.channels}
+	if id3 != nil {
+		meta.setTags(*id3)
+	}
+	if v1 != nil {
+		meta.setTags(*v1)
+	}
+	frame := make([]byte, first.size)
+	if _, err = r.ReadAt(frame, start); err != nil && err != io.EOF {
+		return nil, err
+	}
+	if samples, ok := vbrSamples(frame, first); ok {
+		meta.Duration = samplesDuration(samples, first.sampleRate)
+		return meta, nil
+	}
+	samples, err := countFrameSamples(io.NewSectionReader(r, start, end-start))
+	if err != nil {
+		return nil, err
+	}
+	meta.Duration = samplesDuration(samples, first.sampleRate)
+	return meta, nil
+}
+
+// findFirstFrame looks for two consecutive valid frame headers so that a
+// stray sync word inside junk data is not mistaken for audio.
+func findFirstFrame(r io.ReaderAt, offset, end int64) (int64, mpegFrame, error) {
+	const window = 64 * 1024
+	buf := make([]byte, window+4)
+	next := make([]byte, 4)
+	for pos := offset; pos < end; pos += window {
+		n, _ := r.ReadAt(buf, pos)
+		for i := 0; i+4 <= n; i++ {
+			f, ok := parseMPEGHeader(buf[i:])
+			if !ok {
+				continue
+			}
+			at := pos + int64(i) + int64(f.size)
+			if at+4 > end {
+				if at <= end {
+					return pos + int64(i), f, nil
+				}
+				continue
+			}
+			if _, err := r.ReadAt(next, at); err != nil {
+				continue
+			}
+			if g, ok := parseMPEGHeader(next); ok && g.version == f.version && g.layer == f.layer {
+				return pos + int64(i), f, nil
+			}
+		}
+		if n < len(buf) {
+			break
+		}
+	}
+	return 0, mpegFrame{}, ErrUnknownFormat
+}
+
+// vbrSamples reads the sample count from a Xing/Info or VBRI header stored in
+// the first frame, taking the LAME encoder delay and padding into account.
+func vbrSamples(frame []byte, f mpegFrame) (uint64, bool) {
+	at := 4 + f.sideInfoSize()
+	if len(frame) >= at+8 && (bytes.Equal(frame[at:at+4], []byte("Xing")) || bytes.Equal(frame[at:at+4], []byte("Info"))) {
+		flags := binary.BigEndian.Uint32(frame[at+4:])
+		if flags&1 == 0 || len(frame) < at+12 {
+			return 0, false
+		}
+		samples := uint64(binary.BigEndian.Uint32(frame[at+8:])) * uint64(f.samples)
+		pos := at + 12
+		if flags&2 != 0 {
+			pos += 4
+		}
+		if flags&4 != 0 {
+			pos += 100
+		}
+		if flags&8 != 0 {
+			pos += 4
+		}
+		if len(frame) >= pos+24 && (bytes.HasPrefix(frame[pos:], []byte("LAME")) || bytes.HasPrefix(frame[pos:], []byte("Lav"))) {
+			d := frame[pos+21:]
+			delay := uint64(d[0])<<4 | uint64(d[1]>>4)
+			padding := uint64(d[1]&0x0f)<<8 | uint64(d[2])
+			if delay+padding < samples {
+				samples -= delay + padding
+			}
+		}
+		return samples, true
+	}
+	at = 4 + 32
+	if len(frame) >= at+18 && bytes.Equal(frame[at:at+4], []byte("VBRI")) {
+		return uint64(binary.BigEndian.Uint32(frame[at+14:])) * uint64(f.samples), true
+	}
+	return 0, false
+}
+
+func countFrameSamples(r io.Reader) (uint64, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+	var samples uint64
+	for {
+		header, err := br.Peek(4)
+		if err != nil {
+			return samples, nil
+		}
+		f, ok := parseMPEGHeader(header)
+		if !ok {
+			return samples, nil
+		}
+		if _, err = br.Discard(f.size); err != nil {
+			if err == io.EOF {
+				return samples, nil
+			}
+			return 0, err
+		}
+		samples += uint64(f.samples)
+	}
+}
+
+// MP3Layout is the byte range of an MP3 file that holds MPEG frames, i.e. the
+// file without its ID3v2 and ID3v1 tags.
+type MP3Layout struct {
+	Start int64
+	End   int64
+}
+
+func ReadMP3Layout(r io.ReaderAt, size int64) (MP3Layout, error) {
+	_, offset, err := readID3v2(r, 0)
+	if err != nil {
+		return MP3Layout{}, err
+	}
+	end := size
+	if readID3v1(r, size) != nil {
+		end -= 128
+	}
+	start, _, err := findFirstFrame(r, offset, end)
+	if err != nil {
+		return MP3Layout{}, err
+	}
+	return MP3Layout{Start: start, End: end}, nil
+}
+
+// Offset maps position within a track of the given duration to the first frame
+// boundary at or after the proportional byte offset.
+func (l MP3Layout) Offset(r io.ReaderAt, position, duration time.Duration) int64 {
+	if position <= 0 || duration <= 0 {
+		return l.Start
+	}
+	if position >= duration {
+		return l.End
+	}
+	guess := l.Start + int64(float64(l.End-l.Start)*float64(position)/float64(duration))
+	at, _, err := findFirstFrame(r, guess, l.End)
+	if err != nil {
+		return l.End
+	}
+	return at
+}
@@ -0,0 +1,141 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+)
+
+const (
+	oggHeaderSize = 27
+	maxOggPacket  = 16 * 1024 * 1024
+)
+
+type oggPage struct {
+	granule  int64
+	serial   uint32
+	segments []byte
+	offset   int64
+	size     int64
+}
+
+func readOggPage(r io.ReaderAt, offset int64) (*oggPage, error) {
+	header := make([]byte, oggHeaderSize)
+	if _, err := r.ReadAt(header, offset); err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(header, []byte("OggS")) {
+		return nil, ErrMalformed
+	}
+	page := &oggPage{
+		granule:  int64(binary.LittleEndian.Uint64(header[6:14])),
+		serial:   binary.LittleEndian.Uint32(header[14:18]),
+		segments: make([]byte, header[26]),
+		offset:   offset,
+	}
+	if _, err := r.ReadAt(page.segments, offset+oggHeaderSize); err != nil {
+		return nil, err
+	}
+	page.size = oggHeaderSize + int64(len(page.segments))
+	for _, s := range page.segments {
+		page.size += int64(s)
+	}
+	return page, nil
+}
+
+// oggPackets returns the first n packets of the logical stream that starts
+// the file, following packets that are continued across pages.
+func oggPackets(r io.ReaderAt, n int) ([][]byte, uint32, error) {
+	var packets [][]byte
+	var cur []byte
+	var serial uint32
+	for offset, first := int64(0), true; len(packets) < n; first = false {
+		page, err := readOggPage(r, offset)
+		if err != nil {
+			return nil, 0, ErrMalformed
+		}
+		offset += page.size
+		if first {
+			serial = page.serial
+		} else if page.serial != serial {
+			continue
+		}
+		data := make([]byte, page.size-oggHeaderSize-int64(len(page.segments)))
+		if _, err = r.ReadAt(data, page.offset+oggHeaderSize+int64(len(page.segments))); err != nil {
+			return nil, 0, ErrMalformed
+		}
+		for _, s := range page.segments {
+			cur = append(cur, data[:s]...)
+			data = data[s:]
+			if len(cur) > maxOggPacket {
+				return nil, 0, ErrMalformed
+			}
+			if s < 255 {
+				packets = append(packets, cur)
+				cur = nil
+				if len(packets) == n {
+					break
+				}
+			}
+		}
+	}
+	return packets, serial, nil
+}
+
+// lastGranule finds the granule position of the last page of the stream by
+// scanning backwards from the end of the file.
+func lastGranule(r io.ReaderAt, size int64, serial uint32) (int64, bool) {
+	const chunk = 64 * 1024
+	buf := make([]byte, chunk+oggHeaderSize)
+	for end := size; end > 0; end -= chunk {
+		start := end - chunk
+		if start < 0 {
+			start = 0
+		}
+		n, _ := r.ReadAt(buf[:end-start+oggHeaderSize], start)
+		b := buf[:n]
+		for i := bytes.LastIndex(b, []byte("OggS")); i >= 0; i = bytes.LastIndex(b[:i], []byte("OggS")) {
+			if i+oggHeaderSize > len(b) {
+				continue
+			}
+			granule := int64(binary.LittleEndian.Uint64(b[i+6:]))
+			if binary.LittleEndian.Uint32(b[i+14:]) == serial && granule >= 0 {
+				return granule, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func readOgg(r io.ReaderAt, size int64) (*Metadata, error) {
+	packets, serial, err := oggPackets(r, 2)
+	if err != nil {
+		return nil, err
+	}
+	ident, comment := packets[0], packets[1]
+	meta := &Metadata{}
+	var preSkip uint64
+	switch {
+	case len(ident) >= 16 && bytes.HasPrefix(ident, []byte("\x01vorbis")):
+		meta.Format = Vorbis
+		meta.Channels = ident[11]
+		meta.SampleRate = binary.LittleEndian.Uint32(ident[12:16])
+		if bytes.HasPrefix(comment, []byte("\x03vorbis")) {
+			meta.setTags(parseVorbisComment(comment[7:]))
+		}
+	case len(ident) >= 19 && bytes.HasPrefix(ident, []byte("OpusHead")):
+		meta.Format = Opus
+		meta.Channels = ident[9]
+		preSkip = uint64(binary.LittleEndian.Uint16(ident[10:12]))
+		meta.SampleRate = 48000
+		if bytes.HasPrefix(comment, []byte("OpusTags")) {
+			meta.setTags(parseVorbisComment(comment[8:]))
+		}
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if granule, ok := lastGranule(r, size, serial); ok && uint64(granule) > preSkip {
+		meta.Duration = samplesDuration(uint64(granule)-preSkip, meta.SampleRate)
+	}
+	return meta, nil
+}
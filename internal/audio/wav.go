@@ -0,0 +1,87 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"strings"
+)
+
+func readWAV(r io.ReaderAt, size int64) (*Metadata, error) {
+	meta := &Metadata{Format: WAV}
+	var blockAlign uint16
+	var dataSize int64 = -1
+	header := make([]byte, 8)
+	for pos := int64(12); pos+8 <= size; {
+		if _, err := r.ReadAt(header, pos); err != nil {
+			break
+		}
+		id := string(header[:4])
+		length := int64(binary.LittleEndian.Uint32(header[4:]))
+		pos += 8
+		switch id {
+		case "fmt ":
+			if length < 16 {
+				return nil, ErrMalformed
+			}
+			fmtChunk := make([]byte, 16)
+			if _, err := r.ReadAt(fmtChunk, pos); err != nil {
+				return nil, ErrMalformed
+			}
+			meta.Channels = uint8(binary.LittleEndian.Uint16(fmtChunk[2:]))
+			meta.SampleRate = binary.LittleEndian.Uint32(fmtChunk[4:])
+			blockAlign = binary.LittleEndian.Uint16(fmtChunk[12:])
+		case "data":
+			dataSize = length
+			if pos+length > size {
+				dataSize = size - pos
+			}
+		case "LIST":
+			if length > 4 && length <= 1024*1024 {
+				list := make([]byte, length)
+				if _, err := r.ReadAt(list, pos); err == nil && bytes.HasPrefix(list, []byte("INFO")) {
+					meta.setTags(parseRIFFInfo(list[4:]))
+				}
+			}
+		case "id3 ", "ID3 ":
+			if t, _, _ := readID3v2(r, pos); t != nil {
+				meta.setTags(*t)
+			}
+		}
+		pos += length + length&1
+	}
+	if blockAlign == 0 || meta.SampleRate == 0 || dataSize < 0 {
+		return nil, ErrMalformed
+	}
+	meta.Duration = samplesDuration(uint64(dataSize)/uint64(blockAlign), meta.SampleRate)
+	return meta, nil
+}
+
+func parseRIFFInfo(b []byte) tags {
+	var t tags
+	for len(b) >= 8 {
+		id := string(b[:4])
+		n := int(binary.LittleEndian.Uint32(b[4:]))
+		if n < 0 || n > len(b)-8 {
+			break
+		}
+		value := string(b[8 : 8+n])
+		if i := strings.IndexByte(value, 0); i >= 0 {
+			value = value[:i]
+		}
+		value = strings.TrimSpace(value)
+		switch id {
+		case "INAM":
+			t.title = value
+		case "IART":
+			t.artist = value
+		case "IPRD":
+			t.album = value
+		}
+		b = b[8+n:]
+		if n&1 == 1 && len(b) > 0 {
+			b = b[1:]
+		}
+	}
+	return t
+}
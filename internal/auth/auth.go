@@ -0,0 +1,211 @@
+// Package auth authenticates callers of the gRPC and HTTP servers with static
+// API keys or with JWTs signed by one of the keys of a JWKS file.
+package auth
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"github.com/sgoldenf/playlist/internal/certs"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNoCredentials = errors.New("auth error: no credentials")
+	ErrBadAPIKey     = errors.New("auth error: unknown API key")
+	ErrBadToken      = errors.New("auth error: invalid token")
+	ErrTokenExpired  = errors.New("auth error: token is expired or not valid yet")
+	ErrBadCert       = errors.New("auth error: client certificate has no subject")
+)
+
+// Methods a Principal was authenticated with.
+const (
+	MethodAPIKey = "api-key"
+	MethodJWT    = "jwt"
+	MethodCert   = "cert"
+)
+
+// DefaultLeeway is the clock skew allowed when checking exp and nbf.
+const DefaultLeeway = time.Minute
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Subject string
+	Method  string
+	// Role is assigned by the policy, it is empty without one.
+	Role Role
+	// Claims are the claims of a JWT, nil for API keys.
+	Claims map[string]interface{}
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, p *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// FromContext returns the caller of the request in ctx. It reports false when
+// authentication is disabled or the method does not require it.
+func FromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+type Config struct {
+	// APIKeysFile lists API keys, one "subject key" pair per line.
+	APIKeysFile string
+	// JWKSFile holds the public keys JWTs are verified with.
+	JWKSFile string
+	// Issuer and Audience, when set, must match the iss and aud claims.
+	Issuer   string
+	Audience string
+	// PolicyFile holds the Policy that assigns roles.
+	PolicyFile string
+}
+
+// Authenticator checks credentials. A nil or empty Authenticator has
+// authentication disabled and lets every request through.
+type Authenticator struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+	// ClientCerts accepts verified TLS client certificates as credentials.
+	ClientCerts bool
+
+	// keys maps SHA-256 hashes of API keys to subjects, so that looking a key
+	// up does not leak it through timing.
+	keys   map[[sha256.Size]byte]string
+	jwks   *KeySet
+	policy *Policy
+	now    func() time.Time
+}
+
+func New(config Config) (*Authenticator, error) {
+	a := &Authenticator{Issuer: config.Issuer, Audience: config.Audience, Leeway: DefaultLeeway}
+	if config.APIKeysFile != "" {
+		f, err := os.Open(config.APIKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		if err = a.LoadAPIKeys(f); err != nil {
+			return nil, fmt.Errorf("%s: %w", config.APIKeysFile, err)
+		}
+	}
+	if config.JWKSFile != "" {
+		data, err := os.ReadFile(config.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		if a.jwks, err = ParseJWKS(data); err != nil {
+			return nil, fmt.Errorf("%s: %w", config.JWKSFile, err)
+		}
+	}
+	if config.PolicyFile != "" {
+		policy, err := LoadPolicy(config.PolicyFile)
+		if err != nil {
+			return nil, err
+		}
+		a.policy = policy
+	}
+	return a, nil
+}
+
+// Enabled reports whether any credentials are configured.
+func (a *Authenticator) Enabled() bool {
+	return a != nil && (len(a.keys) > 0 || a.jwks != nil || a.ClientCerts)
+}
+
+// AddAPIKey lets requests with key in as subject.
+func (a *Authenticator) AddAPIKey(subject, key string) {
+	if a.keys == nil {
+		a.keys = make(map[[sha256.Size]byte]string)
+	}
+	a.keys[sha256.Sum256([]byte(key))] = subject
+}
+
+// LoadAPIKeys reads "subject key" lines. Empty lines and lines starting with
+// # are skipped.
+func (a *Authenticator) LoadAPIKeys(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: want \"subject key\"", n)
+		}
+		a.AddAPIKey(fields[0], fields[1])
+	}
+	return sc.Err()
+}
+
+// SetJWKS replaces the keys JWTs are verified with.
+func (a *Authenticator) SetJWKS(keys *KeySet) {
+	a.jwks = keys
+}
+
+// Authenticate checks the value of an Authorization header ("Bearer <token>",
+// where the token is an API key or a JWT) or an API key sent on its own.
+func (a *Authenticator) Authenticate(authorization, apiKey string) (*Principal, error) {
+	p, err := a.authenticate(authorization, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	return a.withRole(p), nil
+}
+
+// AuthenticateCert identifies the client by a certificate the TLS handshake
+// verified already, see certs.Subject.
+func (a *Authenticator) AuthenticateCert(cert *x509.Certificate) (*Principal, error) {
+	subject := certs.Subject(cert)
+	if subject == "" {
+		return nil, ErrBadCert
+	}
+	return a.withRole(&Principal{Subject: subject, Method: MethodCert}), nil
+}
+
+func (a *Authenticator) withRole(p *Principal) *Principal {
+	if a.policy != nil {
+		p.Role = a.policy.RoleOf(p)
+	}
+	return p
+}
+
+func (a *Authenticator) authenticate(authorization, apiKey string) (*Principal, error) {
+	if apiKey != "" {
+		return a.apiKey(apiKey)
+	}
+	scheme, token, _ := strings.Cut(strings.TrimSpace(authorization), " ")
+	token = strings.TrimSpace(token)
+	if !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, ErrNoCredentials
+	}
+	if strings.Count(token, ".") == 2 && a.jwks != nil {
+		return a.verifyJWT(token)
+	}
+	return a.apiKey(token)
+}
+
+func (a *Authenticator) apiKey(key string) (*Principal, error) {
+	subject, ok := a.keys[sha256.Sum256([]byte(key))]
+	if !ok {
+		return nil, ErrBadAPIKey
+	}
+	return &Principal{Subject: subject, Method: MethodAPIKey}, nil
+}
+
+func (a *Authenticator) time() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,362 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var b64 = base64.RawURLEncoding
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+	ed  ed25519.PrivateKey
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testKeys{rsa: rsaKey, ec: ecKey, ed: edKey}
+}
+
+func (k *testKeys) jwks() []byte {
+	pub := k.ed.Public().(ed25519.PublicKey)
+	doc := map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "n": b64.EncodeToString(k.rsa.N.Bytes()), "e": b64.EncodeToString(big.NewInt(int64(k.rsa.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": b64.EncodeToString(k.ec.X.FillBytes(make([]byte, 32))), "y": b64.EncodeToString(k.ec.Y.FillBytes(make([]byte, 32)))},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": b64.EncodeToString(pub)},
+		{"kty": "oct", "kid": "secret", "k": "c2VjcmV0"},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+	}}
+	data, _ := json.Marshal(doc)
+	return data
+}
+
+func (k *testKeys) sign(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	input := b64.EncodeToString(header) + "." + b64.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(input))
+	var sig []byte
+	var err error
+	switch alg {
+	case "RS256":
+		sig, err = rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+	case "PS256":
+		sig, err = rsa.SignPSS(rand.Reader, k.rsa, crypto.SHA256, digest[:], &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+	case "ES256":
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		if err == nil {
+			sig = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		}
+	case "EdDSA":
+		sig = ed25519.Sign(k.ed, []byte(input))
+	case "none":
+	default:
+		t.Fatalf("unexpected alg %s", alg)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + b64.EncodeToString(sig)
+}
+
+func TestParseJWKS(t *testing.T) {
+	keys := newTestKeys(t)
+	set, err := ParseJWKS(keys.jwks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.keys) != 3 {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", 3, len(set.keys))
+	}
+	tests := []struct {
+		in      string
+		wantErr error
+	}{
+		{`{"keys": []}`, ErrNoKeys},
+		{`{"keys": [{"kty": "oct", "k": "c2VjcmV0"}]}`, ErrNoKeys},
+		{`{"keys": [{"kty": "EC", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`, nil},
+		{`{"keys": [{"kty": "RSA", "n": "AQAB", "e": "AQ"}]}`, nil},
+		{`not json`, nil},
+	}
+	for _, test := range tests {
+		_, err = ParseJWKS([]byte(test.in))
+		if err == nil || (test.wantErr != nil && !errors.Is(err, test.wantErr)) {
+			t.Errorf("%s -> \nWant: %v\nGot : %v", test.in, test.wantErr, err)
+		}
+	}
+}
+
+func TestAuthenticator_JWT(t *testing.T) {
+	keys := newTestKeys(t)
+	set, err := ParseJWKS(keys.jwks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Unix(1700000000, 0)
+	a := &Authenticator{Issuer: "https://id.example.com", Audience: "playlist", Leeway: DefaultLeeway, now: func() time.Time { return now }}
+	a.SetJWKS(set)
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{"sub": "alice", "iss": "https://id.example.com", "aud": []string{"other", "playlist"}, "exp": now.Add(time.Hour).Unix()}
+		for name, value := range changes {
+			if value == nil {
+				delete(c, name)
+			} else {
+				c[name] = value
+			}
+		}
+		return c
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"RS256", keys.sign(t, "RS256", "rsa", claims(nil)), nil},
+		{"PS256", keys.sign(t, "PS256", "rsa", claims(nil)), nil},
+		{"ES256", keys.sign(t, "ES256", "ec", claims(nil)), nil},
+		{"EdDSA", keys.sign(t, "EdDSA", "ed", claims(nil)), nil},
+		{"no kid", keys.sign(t, "ES256", "", claims(nil)), nil},
+		{"string aud", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"aud": "playlist"})), nil},
+		{"within leeway", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-30 * time.Second).Unix()})), nil},
+		{"expired", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"exp": now.Add(-time.Hour).Unix()})), ErrTokenExpired},
+		{"not yet valid", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"nbf": now.Add(time.Hour).Unix()})), ErrTokenExpired},
+		{"issuer", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"iss": "https://evil.example.com"})), ErrBadToken},
+		{"audience", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"aud": "other"})), ErrBadToken},
+		{"no subject", keys.sign(t, "RS256", "rsa", claims(map[string]interface{}{"sub": nil})), ErrBadToken},
+		{"wrong kid", keys.sign(t, "RS256", "ec", claims(nil)), ErrBadToken},
+		{"alg none", keys.sign(t, "none", "rsa", claims(nil)), ErrBadToken},
+		{"tampered", strings.Replace(keys.sign(t, "EdDSA", "ed", claims(nil)), ".", ".e30", 1), ErrBadToken},
+	}
+	for _, test := range tests {
+		p, err := a.Authenticate("Bearer "+test.token, "")
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s -> \nWant: %v\nGot : %v", test.name, test.wantErr, err)
+			continue
+		}
+		if err == nil && (p.Subject != "alice" || p.Method != MethodJWT || p.Claims["iss"] != a.Issuer) {
+			t.Errorf("%s -> unexpected principal %+v", test.name, p)
+		}
+	}
+}
+
+func TestAuthenticator_APIKey(t *testing.T) {
+	a := &Authenticator{}
+	if a.Enabled() || (*Authenticator)(nil).Enabled() {
+		t.Fatal("expected authentication without keys to be disabled")
+	}
+	err := a.LoadAPIKeys(strings.NewReader("# office\nalice  k-alice\n\nbob k-bob\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Enabled() {
+		t.Fatal("expected authentication to be enabled")
+	}
+	tests := []struct {
+		authorization, apiKey string
+		want                  string
+		wantErr               error
+	}{
+		{"", "k-alice", "alice", nil},
+		{"Bearer k-bob", "", "bob", nil},
+		{"bearer  k-bob ", "", "bob", nil},
+		{"Basic k-bob", "", "", ErrNoCredentials},
+		{"", "", "", ErrNoCredentials},
+		{"", "k-eve", "", ErrBadAPIKey},
+		{"Bearer a.b.c", "", "", ErrBadAPIKey},
+	}
+	for _, test := range tests {
+		p, err := a.Authenticate(test.authorization, test.apiKey)
+		if !errors.Is(err, test.wantErr) || (err == nil && (p.Subject != test.want || p.Method != MethodAPIKey)) {
+			t.Errorf("%q %q -> \nWant: %v %v\nGot : %+v %v", test.authorization, test.apiKey, test.want, test.wantErr, p, err)
+		}
+	}
+	if err = a.LoadAPIKeys(strings.NewReader("alice\n")); err == nil {
+		t.Errorf("expected a line without a key to fail")
+	}
+}
+
+func TestNew(t *testing.T) {
+	dir := t.TempDir()
+	keysFile := filepath.Join(dir, "keys")
+	jwksFile := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(keysFile, []byte("alice k-alice\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(jwksFile, newTestKeys(t).jwks(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	a, err := New(Config{APIKeysFile: keysFile, JWKSFile: jwksFile, Issuer: "iss"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Enabled() || a.jwks == nil || a.Issuer != "iss" {
+		t.Errorf("unexpected authenticator %+v", a)
+	}
+	if _, err = New(Config{JWKSFile: keysFile}); err == nil {
+		t.Errorf("expected a bad JWKS file to fail")
+	}
+	if a, err = New(Config{}); err != nil || a.Enabled() {
+		t.Errorf("empty config -> %v %v", a.Enabled(), err)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	a := &Authenticator{}
+	a.AddAPIKey("alice", "k-alice")
+	interceptor := UnaryInterceptor(a)
+	var got *Principal
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = FromContext(ctx)
+		return nil, nil
+	}
+	play := &grpc.UnaryServerInfo{FullMethod: "/playlist.PlaylistService/Play"}
+	health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	tests := []struct {
+		md   metadata.MD
+		info *grpc.UnaryServerInfo
+		want codes.Code
+		sub  string
+	}{
+		{metadata.Pairs("authorization", "Bearer k-alice"), play, codes.OK, "alice"},
+		{metadata.Pairs(APIKeyHeader, "k-alice"), play, codes.OK, "alice"},
+		{metadata.Pairs(APIKeyHeader, "k-eve"), play, codes.Unauthenticated, ""},
+		{metadata.MD{}, play, codes.Unauthenticated, ""},
+		{metadata.MD{}, health, codes.OK, ""},
+	}
+	for _, test := range tests {
+		got = nil
+		ctx := metadata.NewIncomingContext(context.Background(), test.md)
+		_, err := interceptor(ctx, nil, test.info, handler)
+		if status.Code(err) != test.want || (test.sub != "" && (got == nil || got.Subject != test.sub)) {
+			t.Errorf("%v %s -> \nWant: %v %s\nGot : %v %+v", test.md, test.info.FullMethod, test.want, test.sub, err, got)
+		}
+	}
+
+	_, err := UnaryInterceptor(nil)(context.Background(), nil, play, handler)
+	if err != nil {
+		t.Errorf("disabled authentication -> %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	a := &Authenticator{}
+	a.AddAPIKey("alice", "k-alice")
+	r := gin.New()
+	r.Use(Middleware(a, func(*gin.Context) string { return "GetSongs" }))
+	r.GET("/v1/songs", func(c *gin.Context) {
+		p, _ := FromContext(c.Request.Context())
+		c.String(http.StatusOK, p.Subject)
+	})
+	tests := []struct {
+		url    string
+		header string
+		value  string
+		want   int
+	}{
+		{"/v1/songs", "Authorization", "Bearer k-alice", http.StatusOK},
+		{"/v1/songs", "X-Api-Key", "k-alice", http.StatusOK},
+		{"/v1/songs?access_token=k-alice", "", "", http.StatusOK},
+		{"/v1/songs?access_token=k-eve", "", "", http.StatusUnauthorized},
+		{"/v1/songs", "", "", http.StatusUnauthorized},
+	}
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		if test.header != "" {
+			req.Header.Set(test.header, test.value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != test.want {
+			t.Errorf("%s %s -> \nWant: %v\nGot : %v %s", test.url, test.header, test.want, w.Code, w.Body)
+			continue
+		}
+		if w.Code == http.StatusOK && w.Body.String() != "alice" {
+			t.Errorf("%s -> subject %q", test.url, w.Body)
+		}
+		if w.Code == http.StatusUnauthorized && (w.Header().Get("WWW-Authenticate") != "Bearer" || !strings.Contains(w.Body.String(), `"code":16`)) {
+			t.Errorf("%s -> %v %s", test.url, w.Header(), w.Body)
+		}
+	}
+}
+
+func TestUnaryInterceptor_ClientCert(t *testing.T) {
+	a := &Authenticator{ClientCerts: true}
+	a.AddAPIKey("bob", "k-bob")
+	var got *Principal
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = FromContext(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/playlist_service.PlaylistService/Play"}
+	withCert := func(cert *x509.Certificate) context.Context {
+		state := tls.ConnectionState{}
+		if cert != nil {
+			state.VerifiedChains = [][]*x509.Certificate{{cert}}
+		}
+		return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+	}
+	alice := &x509.Certificate{Subject: pkix.Name{CommonName: "alice"}}
+	tests := []struct {
+		ctx  context.Context
+		want codes.Code
+		sub  string
+	}{
+		{withCert(alice), codes.OK, "alice"},
+		{metadata.NewIncomingContext(withCert(alice), metadata.Pairs(APIKeyHeader, "k-bob")), codes.OK, "bob"},
+		{withCert(&x509.Certificate{}), codes.Unauthenticated, ""},
+		{withCert(nil), codes.Unauthenticated, ""},
+	}
+	for i, test := range tests {
+		got = nil
+		_, err := UnaryInterceptor(a)(test.ctx, nil, info, handler)
+		if status.Code(err) != test.want || (test.sub != "" && (got == nil || got.Subject != test.sub)) {
+			t.Errorf("%d -> \nWant: %v %s\nGot : %v %+v", i, test.want, test.sub, err, got)
+		}
+	}
+	got = nil
+	_, _ = UnaryInterceptor(a)(withCert(alice), nil, info, handler)
+	if got == nil || got.Method != MethodCert {
+		t.Errorf("unexpected principal %+v", got)
+	}
+}
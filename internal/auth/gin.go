@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/sgoldenf/playlist/internal/logging"
+	"google.golang.org/grpc/codes"
+	"net/http"
+)
+
+// AccessTokenParam carries credentials in the query for clients that cannot
+// set headers, such as EventSource and media players.
+const AccessTokenParam = "access_token"
+
+// Middleware authenticates HTTP requests by header, access_token query
+// parameter or verified client certificate, and checks the role against the
+// PlaylistService method that method maps the request to. Rejected requests
+// get 401 or 403 with the error body of the REST API.
+func Middleware(a *Authenticator, method func(*gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.Enabled() {
+			return
+		}
+		authorization := c.GetHeader("Authorization")
+		if token := c.Query(AccessTokenParam); authorization == "" && token != "" {
+			authorization = "Bearer " + token
+		}
+		apiKey := c.GetHeader(APIKeyHeader)
+		var p *Principal
+		var err error
+		if tls := c.Request.TLS; tls != nil && len(tls.VerifiedChains) > 0 && a.ClientCerts && authorization == "" && apiKey == "" {
+			p, err = a.AuthenticateCert(tls.VerifiedChains[0][0])
+		} else {
+			p, err = a.Authenticate(authorization, apiKey)
+		}
+		if err != nil {
+			ctx := c.Request.Context()
+			logging.FromContext(ctx).Warn("authentication failed", "err", err)
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"code": codes.Unauthenticated, "message": err.Error()})
+			return
+		}
+		ctx := authenticated(c.Request.Context(), p)
+		c.Request = c.Request.WithContext(ctx)
+		if err = a.Authorize(p, method(c)); err != nil {
+			logging.FromContext(ctx).Warn("permission denied", "role", p.Role, "err", err)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"code": codes.PermissionDenied, "message": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"github.com/sgoldenf/playlist/internal/logging"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// APIKeyHeader carries an API key in gRPC metadata and HTTP headers, as an
+// alternative to "Authorization: Bearer <key>".
+const APIKeyHeader = "x-api-key"
+
+// publicPrefixes are methods callers do not authenticate for: load balancers
+// and orchestrators have to reach the health checks.
+var publicPrefixes = []string{"/grpc.health.v1.Health/"}
+
+func isPublic(method string) bool {
+	for _, prefix := range publicPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryInterceptor rejects calls without valid credentials with
+// codes.Unauthenticated and calls the principal's role does not allow with
+// codes.PermissionDenied, and puts the Principal in the context of the others.
+func UnaryInterceptor(a *Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !a.Enabled() || isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+		ctx, err := a.authenticateRPC(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor checks the credentials and role of a streaming call once,
+// when the stream opens, and hands the handler a stream whose context carries
+// the Principal.
+func StreamInterceptor(a *Authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !a.Enabled() || isPublic(info.FullMethod) {
+			return handler(srv, ss)
+		}
+		ctx, err := a.authenticateRPC(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) authenticateRPC(ctx context.Context, fullMethod string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	authorization, apiKey := first(md.Get("authorization")), first(md.Get(APIKeyHeader))
+	var p *Principal
+	var err error
+	if cert := peerCert(ctx); cert != nil && a.ClientCerts && authorization == "" && apiKey == "" {
+		p, err = a.AuthenticateCert(cert)
+	} else {
+		p, err = a.Authenticate(authorization, apiKey)
+	}
+	if err != nil {
+		logging.FromContext(ctx).Warn("authentication failed", "err", err)
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = authenticated(ctx, p)
+	if err = a.Authorize(p, rpcMethod(fullMethod)); err != nil {
+		logging.FromContext(ctx).Warn("permission denied", "role", p.Role, "err", err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	return ctx, nil
+}
+
+// peerCert returns the client certificate verified by the TLS handshake of
+// the connection, or nil.
+func peerCert(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
+
+// authenticated stores p in ctx and tags the logger and span of the request
+// with its subject.
+func authenticated(ctx context.Context, p *Principal) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("enduser.id", p.Subject))
+	ctx = logging.With(ctx, "subject", p.Subject)
+	return NewContext(ctx, p)
+}
+
+func first(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,205 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"os"
+	"strings"
+)
+
+var ErrPermissionDenied = errors.New("auth error: permission denied")
+
+// Role grants access to PlaylistService methods. Each role includes the
+// methods of the roles before it.
+type Role string
+
+const (
+	// RoleViewer sees the library, the playlist and the player.
+	RoleViewer Role = "viewer"
+	// RoleListener also controls the player, streams songs and takes part
+	// in the party queue.
+	RoleListener Role = "listener"
+	// RoleEditor also edits the library and the playlist order.
+	RoleEditor Role = "editor"
+	// RoleAdmin may call everything, including library scans.
+	RoleAdmin Role = "admin"
+)
+
+var roleRanks = map[Role]int{RoleViewer: 1, RoleListener: 2, RoleEditor: 3, RoleAdmin: 4}
+
+func (r Role) valid() bool {
+	_, ok := roleRanks[r]
+	return ok
+}
+
+// Includes reports whether r grants everything other grants.
+func (r Role) Includes(other Role) bool {
+	return roleRanks[r] >= roleRanks[other]
+}
+
+// DefaultMethodRoles is the role each PlaylistService method requires unless
+// a policy says otherwise. Methods that are not listed need RoleAdmin.
+var DefaultMethodRoles = map[string]Role{
+	"GetSong":        RoleViewer,
+	"GetSongs":       RoleViewer,
+	"GetPlaylist":    RoleViewer,
+	"GetPlayerState": RoleViewer,
+	"Player":         RoleViewer,
+	"GetPartyQueue":  RoleViewer,
+
+	"Play":         RoleListener,
+	"Pause":        RoleListener,
+	"Next":         RoleListener,
+	"Prev":         RoleListener,
+	"Seek":         RoleListener,
+	"GetStreamURL": RoleListener,
+	"SuggestSong":  RoleListener,
+	"VoteSong":     RoleListener,
+
+	"CreateSong":         RoleEditor,
+	"CreateSongFromFile": RoleEditor,
+	"UpdateSong":         RoleEditor,
+	"DeleteSong":         RoleEditor,
+	"BatchCreateSongs":   RoleEditor,
+	"BatchDeleteSongs":   RoleEditor,
+	"ImportSongs":        RoleEditor,
+	"MoveSong":           RoleEditor,
+
+	"ScanLibrary": RoleAdmin,
+}
+
+// Policy assigns roles to principals and says which role each method needs.
+//
+//	{
+//	  "default_role": "listener",
+//	  "role_claim": "roles",
+//	  "subjects": {"alice": "admin", "kiosk": "viewer"},
+//	  "methods": {"MoveSong": "admin", "DeleteSong": "admin"}
+//	}
+type Policy struct {
+	// DefaultRole is the role of principals that are not listed in Subjects
+	// and have no role claim. Empty denies them everything.
+	DefaultRole Role `json:"default_role"`
+	// RoleClaim names a JWT claim, a string or a list of strings, whose
+	// highest role is used for subjects that are not listed.
+	RoleClaim string `json:"role_claim"`
+	// Subjects maps API key subjects and JWT sub claims to roles.
+	Subjects map[string]Role `json:"subjects"`
+	// Methods overrides DefaultMethodRoles.
+	Methods map[string]Role `json:"methods"`
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p, err := ParsePolicy(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// ParsePolicy decodes a JSON policy. Unknown roles and methods are errors, so
+// that a typo does not silently change who may do what.
+func ParsePolicy(data []byte) (*Policy, error) {
+	p := &Policy{}
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+	if err := d.Decode(p); err != nil {
+		return nil, fmt.Errorf("policy error: %w", err)
+	}
+	if p.DefaultRole != "" && !p.DefaultRole.valid() {
+		return nil, fmt.Errorf("policy error: unknown default role %q", p.DefaultRole)
+	}
+	for subject, role := range p.Subjects {
+		if !role.valid() {
+			return nil, fmt.Errorf("policy error: unknown role %q of %s", role, subject)
+		}
+	}
+	for method, role := range p.Methods {
+		if _, ok := DefaultMethodRoles[method]; !ok {
+			return nil, fmt.Errorf("policy error: unknown method %s", method)
+		}
+		if !role.valid() {
+			return nil, fmt.Errorf("policy error: unknown role %q of %s", role, method)
+		}
+	}
+	return p, nil
+}
+
+// RoleOf returns the role of principal, or "" when it has none.
+func (p *Policy) RoleOf(principal *Principal) Role {
+	if role, ok := p.Subjects[principal.Subject]; ok {
+		return role
+	}
+	if p.RoleClaim != "" {
+		if role := highestRole(principal.Claims[p.RoleClaim]); role != "" {
+			return role
+		}
+	}
+	return p.DefaultRole
+}
+
+func highestRole(claim interface{}) Role {
+	var best Role
+	consider := func(value interface{}) {
+		if s, ok := value.(string); ok && Role(s).valid() && !best.Includes(Role(s)) {
+			best = Role(s)
+		}
+	}
+	if values, ok := claim.([]interface{}); ok {
+		for _, value := range values {
+			consider(value)
+		}
+	} else {
+		consider(claim)
+	}
+	return best
+}
+
+// MethodRole returns the role method needs. method is the name of a
+// PlaylistService method, or the full name of a method of another service.
+func (p *Policy) MethodRole(method string) Role {
+	if role, ok := p.Methods[method]; ok {
+		return role
+	}
+	if role, ok := DefaultMethodRoles[method]; ok {
+		return role
+	}
+	return RoleAdmin
+}
+
+// SetPolicy enables role checks, nil lets every authenticated principal call
+// every method.
+func (a *Authenticator) SetPolicy(p *Policy) {
+	a.policy = p
+}
+
+// Authorize checks that principal may call method. It always succeeds when
+// authentication or the policy is disabled.
+func (a *Authenticator) Authorize(principal *Principal, method string) error {
+	if !a.Enabled() || a.policy == nil {
+		return nil
+	}
+	if principal == nil {
+		return ErrNoCredentials
+	}
+	if need := a.policy.MethodRole(method); principal.Role == "" || !principal.Role.Includes(need) {
+		return fmt.Errorf("%w: %s needs the %s role", ErrPermissionDenied, method, need)
+	}
+	return nil
+}
+
+// rpcMethod returns the name Policy uses for a full gRPC method name.
+func rpcMethod(fullMethod string) string {
+	prefix := "/" + ps.PlaylistService_ServiceDesc.ServiceName + "/"
+	if strings.HasPrefix(fullMethod, prefix) {
+		return fullMethod[len(prefix):]
+	}
+	return fullMethod
+}
@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const testPolicy = `{
+	"default_role": "listener",
+	"role_claim": "roles",
+	"subjects": {"alice": "admin", "kiosk": "viewer"},
+	"methods": {"DeleteSong": "admin"}
+}`
+
+func TestParsePolicy(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.DefaultRole != RoleListener || p.Subjects["kiosk"] != RoleViewer || p.MethodRole("DeleteSong") != RoleAdmin {
+		t.Errorf("unexpected policy %+v", p)
+	}
+	for _, in := range []string{
+		`{"default_role": "root"}`,
+		`{"subjects": {"bob": "owner"}}`,
+		`{"methods": {"DropSongs": "admin"}}`,
+		`{"methods": {"Play": "nobody"}}`,
+		`{"roles": {}}`,
+		`[]`,
+	} {
+		if _, err = ParsePolicy([]byte(in)); err == nil {
+			t.Errorf("%s -> expected an error", in)
+		}
+	}
+}
+
+func TestPolicy_RoleOf(t *testing.T) {
+	p, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		principal *Principal
+		want      Role
+	}{
+		{&Principal{Subject: "alice"}, RoleAdmin},
+		{&Principal{Subject: "kiosk", Claims: map[string]interface{}{"roles": "admin"}}, RoleViewer},
+		{&Principal{Subject: "bob", Claims: map[string]interface{}{"roles": []interface{}{"viewer", "editor", "owner"}}}, RoleEditor},
+		{&Principal{Subject: "bob", Claims: map[string]interface{}{"roles": "owner"}}, RoleListener},
+		{&Principal{Subject: "bob"}, RoleListener},
+	}
+	for _, test := range tests {
+		if got := p.RoleOf(test.principal); got != test.want {
+			t.Errorf("%+v -> \nWant: %v\nGot : %v", test.principal, test.want, got)
+		}
+	}
+	p.DefaultRole = ""
+	if got := p.RoleOf(&Principal{Subject: "bob"}); got != "" {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", "", got)
+	}
+}
+
+func TestAuthenticator_Authorize(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Authenticator{}
+	a.AddAPIKey("alice", "k-alice")
+	a.AddAPIKey("kiosk", "k-kiosk")
+	a.AddAPIKey("bob", "k-bob")
+	if err = a.Authorize(&Principal{Subject: "kiosk"}, "ScanLibrary"); err != nil {
+		t.Errorf("without a policy -> %v", err)
+	}
+	a.SetPolicy(policy)
+	tests := []struct {
+		key, method string
+		want        bool
+	}{
+		{"k-kiosk", "GetSongs", true},
+		{"k-kiosk", "Player", true},
+		{"k-kiosk", "Next", false},
+		{"k-bob", "Next", true},
+		{"k-bob", "GetStreamURL", true},
+		{"k-bob", "UpdateSong", false},
+		{"k-bob", "DeleteSong", false},
+		{"k-alice", "DeleteSong", true},
+		{"k-alice", "ScanLibrary", true},
+		{"k-bob", "/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo", false},
+	}
+	for _, test := range tests {
+		p, err := a.Authenticate("", test.key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = a.Authorize(p, test.method)
+		if (err == nil) != test.want || (err != nil && !errors.Is(err, ErrPermissionDenied)) {
+			t.Errorf("%s %s -> \nWant: %v\nGot : %v", test.key, test.method, test.want, err)
+		}
+	}
+}
+
+func TestRBACInterceptors(t *testing.T) {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := &Authenticator{}
+	a.AddAPIKey("kiosk", "k-kiosk")
+	a.SetPolicy(policy)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	method := func(name string) *grpc.UnaryServerInfo {
+		return &grpc.UnaryServerInfo{FullMethod: "/" + ps.PlaylistService_ServiceDesc.ServiceName + "/" + name}
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(APIKeyHeader, "k-kiosk"))
+	if _, err = UnaryInterceptor(a)(ctx, nil, method("GetSongs"), handler); err != nil {
+		t.Errorf("GetSongs -> %v", err)
+	}
+	if _, err = UnaryInterceptor(a)(ctx, nil, method("Next"), handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Next -> %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(Middleware(a, func(c *gin.Context) string { return strings.TrimPrefix(c.FullPath(), "/") }))
+	r.POST("/Next", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/GetSongs", func(c *gin.Context) { c.Status(http.StatusOK) })
+	for path, want := range map[string]int{"/GetSongs": http.StatusOK, "/Next": http.StatusForbidden} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if path == "/Next" {
+			req.Method = http.MethodPost
+		}
+		req.Header.Set(APIKeyHeader, "k-kiosk")
+		r.ServeHTTP(w, req)
+		if w.Code != want || (want == http.StatusForbidden && !strings.Contains(w.Body.String(), `"code":7`)) {
+			t.Errorf("%s -> \nWant: %v\nGot : %v %s", path, want, w.Code, w.Body)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Package certs serves TLS certificates from files and reloads them when the
+// files change, so that rotated certificates are picked up without a restart.
+package certs
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"golang.org/x/exp/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+var ErrNoCACerts = errors.New("certs error: no certificates found in the CA file")
+
+// DefaultReloadInterval is how often Watch checks the files for changes.
+const DefaultReloadInterval = 10 * time.Second
+
+type Config struct {
+	CertFile string
+	KeyFile  string
+	// ClientCAFile enables verification of client certificates (mutual TLS)
+	// against the CAs in it.
+	ClientCAFile string
+	// RequireClientCert rejects clients without a certificate, otherwise
+	// certificates are only verified when a client sends one.
+	RequireClientCert bool
+}
+
+// Reloader holds the current certificate and client CAs.
+type Reloader struct {
+	config Config
+
+	m         sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// stamps are the modification times and sizes of the loaded files.
+	stamps map[string]string
+}
+
+func New(config Config) (*Reloader, error) {
+	r := &Reloader{config: config}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.config.CertFile, r.config.KeyFile}
+	if r.config.ClientCAFile != "" {
+		files = append(files, r.config.ClientCAFile)
+	}
+	return files
+}
+
+func stamps(files []string) map[string]string {
+	res := make(map[string]string, len(files))
+	for _, file := range files {
+		if stat, err := os.Stat(file); err == nil {
+			res[file] = fmt.Sprintf("%d/%d", stat.ModTime().UnixNano(), stat.Size())
+		}
+	}
+	return res
+}
+
+// Reload reads the files again. On error the previous certificate stays in
+// use.
+func (r *Reloader) Reload() error {
+	files := r.files()
+	stamps := stamps(files)
+	cert, err := tls.LoadX509KeyPair(r.config.CertFile, r.config.KeyFile)
+	if err != nil {
+		return err
+	}
+	var pool *x509.CertPool
+	if r.config.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.config.ClientCAFile)
+		if err != nil {
+			return err
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return ErrNoCACerts
+		}
+	}
+	r.m.Lock()
+	r.cert, r.clientCAs, r.stamps = &cert, pool, stamps
+	r.m.Unlock()
+	return nil
+}
+
+func (r *Reloader) changed() bool {
+	current := stamps(r.files())
+	r.m.RLock()
+	defer r.m.RUnlock()
+	if len(current) != len(r.stamps) {
+		return true
+	}
+	for file, stamp := range current {
+		if r.stamps[file] != stamp {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch reloads the files every interval when they changed until ctx is
+// done. A certificate and key that are replaced one after the other may not
+// match for a moment, the next check picks up the complete pair.
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !r.changed() {
+				continue
+			}
+			if err := r.Reload(); err != nil {
+				slog.Warn("failed to reload certificates", "err", err)
+			} else {
+				slog.Info("reloaded certificates", "cert", r.config.CertFile)
+			}
+		}
+	}
+}
+
+// Certificate returns the certificate in use.
+func (r *Reloader) Certificate() *tls.Certificate {
+	r.m.RLock()
+	defer r.m.RUnlock()
+	return r.cert
+}
+
+// ServerConfig returns a TLS configuration that serves the current
+// certificate and verifies clients against the current CAs on every
+// handshake.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.m.RLock()
+			defer r.m.RUnlock()
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*r.cert},
+				NextProtos:   []string{"h2"},
+			}
+			if r.clientCAs != nil {
+				config.ClientCAs = r.clientCAs
+				config.ClientAuth = tls.VerifyClientCertIfGiven
+				if r.config.RequireClientCert {
+					config.ClientAuth = tls.RequireAndVerifyClientCert
+				}
+			}
+			return config, nil
+		},
+	}
+}
+
+// Subject returns the identity of a client certificate: its common name, or
+// else its first URI, DNS or email subject alternative name.
+func Subject(cert *x509.Certificate) string {
+	switch {
+	case cert.Subject.CommonName != "":
+		return cert.Subject.CommonName
+	case len(cert.URIs) > 0:
+		return cert.URIs[0].String()
+	case len(cert.DNSNames) > 0:
+		return cert.DNSNames[0]
+	case len(cert.EmailAddresses) > 0:
+		return cert.EmailAddresses[0]
+	}
+	return ""
+}
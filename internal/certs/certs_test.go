@@ -0,0 +1,267 @@
+package certs
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue writes a certificate for name and its key to dir and returns their
+// paths.
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	file := filepath.Join(dir, "ca.crt")
+	writePEM(t, file, "CERTIFICATE", ca.cert.Raw)
+	return file
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	// Write and rename, as a certificate manager would, so that a reload
+	// never sees a partial file.
+	tmp := file + ".tmp"
+	if err := os.WriteFile(tmp, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func serveHealth(t *testing.T, r *Reloader) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := grpc.NewServer(grpc.Creds(credentials.NewTLS(r.ServerConfig())))
+	healthpb.RegisterHealthServer(s, health.NewServer())
+	go s.Serve(l)
+	t.Cleanup(s.Stop)
+	return l.Addr().String()
+}
+
+func check(addr string, flags cli.ConnFlags) error {
+	flags.Addr = addr
+	conn, err := flags.Dial()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestReloader_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+	certFile, keyFile := ca.issue(t, dir, "server", 2, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "alice", 3, x509.ExtKeyUsageClientAuth)
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := serveHealth(t, r)
+
+	if err = check(addr, cli.ConnFlags{CAFile: caFile, CertFile: clientCert, KeyFile: clientKey}); err != nil {
+		t.Errorf("client with a certificate -> %v", err)
+	}
+	if err = check(addr, cli.ConnFlags{CAFile: caFile}); err == nil {
+		t.Errorf("expected a client without a certificate to be rejected")
+	}
+	other := newTestCA(t)
+	otherCert, otherKey := other.issue(t, t.TempDir(), "mallory", 4, x509.ExtKeyUsageClientAuth)
+	if err = check(addr, cli.ConnFlags{CAFile: caFile, CertFile: otherCert, KeyFile: otherKey}); err == nil {
+		t.Errorf("expected a certificate of another CA to be rejected")
+	}
+}
+
+// gRPC-Web serves browsers, and websockets over HTTP/1.1, with the same
+// configuration as gRPC.
+func TestReloader_HTTPS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+	certFile, keyFile := ca.issue(t, dir, "server", 2, x509.ExtKeyUsageServerAuth)
+	clientCert, clientKey := ca.issue(t, dir, "alice", 3, x509.ExtKeyUsageClientAuth)
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: caFile, RequireClientCert: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(req.Proto))
+	}))
+	ts.TLS = r.ServerConfig()
+	ts.StartTLS()
+	defer ts.Close()
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		certs []tls.Certificate
+		proto string
+	}{
+		{[]tls.Certificate{cert}, "HTTP/1.1"},
+		{nil, ""},
+	}
+	for _, test := range tests {
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, Certificates: test.certs, NextProtos: []string{"http/1.1"}}}}
+		proto := ""
+		if res, errGet := client.Get(ts.URL); errGet == nil {
+			body, _ := io.ReadAll(res.Body)
+			res.Body.Close()
+			proto = string(body)
+		}
+		if proto != test.proto {
+			t.Errorf("Out -> \nWant: %q\nGot : %q", test.proto, proto)
+		}
+	}
+}
+
+func TestReloader_Watch(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, dir, "server", 2, x509.ExtKeyUsageServerAuth)
+	r, err := New(Config{CertFile: certFile, KeyFile: keyFile})
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := serveHealth(t, r)
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	serial := func() int64 {
+		conn, err := tls.Dial("tcp", addr, &tls.Config{RootCAs: pool, ServerName: "localhost", NextProtos: []string{"h2"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+		return conn.ConnectionState().PeerCertificates[0].SerialNumber.Int64()
+	}
+	if got := serial(); got != 2 {
+		t.Fatalf("Out -> \nWant: %v\nGot : %v", 2, got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go r.Watch(ctx, 10*time.Millisecond)
+	// Make sure the modification time differs on filesystems with a coarse
+	// clock.
+	time.Sleep(20 * time.Millisecond)
+	ca.issue(t, dir, "server", 5, x509.ExtKeyUsageServerAuth)
+	deadline := time.Now().Add(3 * time.Second)
+	for serial() != 5 {
+		if time.Now().After(deadline) {
+			t.Fatal("the rotated certificate was not picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if err = os.WriteFile(keyFile, []byte("garbage"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err = r.Reload(); err == nil {
+		t.Fatal("expected a broken key to fail")
+	}
+	if got := serial(); got != 5 {
+		t.Errorf("a failed reload replaced the certificate: serial %d", got)
+	}
+}
+
+func TestSubject(t *testing.T) {
+	uri, _ := url.Parse("spiffe://example.com/kiosk")
+	tests := []struct {
+		cert *x509.Certificate
+		want string
+	}{
+		{&x509.Certificate{Subject: pkix.Name{CommonName: "alice"}, DNSNames: []string{"a.example.com"}}, "alice"},
+		{&x509.Certificate{URIs: []*url.URL{uri}, DNSNames: []string{"a.example.com"}}, "spiffe://example.com/kiosk"},
+		{&x509.Certificate{DNSNames: []string{"a.example.com"}}, "a.example.com"},
+		{&x509.Certificate{EmailAddresses: []string{"bob@example.com"}}, "bob@example.com"},
+		{&x509.Certificate{}, ""},
+	}
+	for _, test := range tests {
+		if got := Subject(test.cert); got != test.want {
+			t.Errorf("Out -> \nWant: %v\nGot : %v", test.want, got)
+		}
+	}
+}
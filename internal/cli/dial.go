@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"os"
+)
+
+const DefaultAddr = "localhost:50051"
+
+// APIKeyEnv and TokenEnv are read when -api-key and -token are not given, so
+// that credentials do not show up in the process list.
+const (
+	APIKeyEnv = "PLAYLIST_API_KEY"
+	TokenEnv  = "PLAYLIST_TOKEN"
+)
+
+type ConnFlags struct {
+	Addr               string
+	TLS                bool
+	CAFile             string
+	ServerName         string
+	InsecureSkipVerify bool
+	CertFile           string
+	KeyFile            string
+	APIKey             string
+	Token              string
+}
+
+func (f *ConnFlags) Register(fs *flag.FlagSet) {
+	fs.StringVar(&f.Addr, "addr", DefaultAddr, "server address")
+	fs.BoolVar(&f.TLS, "tls", false, "connect over TLS")
+	fs.StringVar(&f.CAFile, "ca", "", "PEM file with CA certificates to verify the server (implies -tls)")
+	fs.StringVar(&f.ServerName, "server-name", "", "server name to verify instead of the host of -addr")
+	fs.BoolVar(&f.InsecureSkipVerify, "insecure-skip-verify", false, "do not verify the server certificate")
+	fs.StringVar(&f.CertFile, "cert", "", "PEM client certificate for servers that verify clients (implies -tls)")
+	fs.StringVar(&f.KeyFile, "key", "", "PEM private key of -cert")
+	fs.StringVar(&f.APIKey, "api-key", "", "API key to authenticate with (default $"+APIKeyEnv+")")
+	fs.StringVar(&f.Token, "token", "", "JWT to authenticate with (default $"+TokenEnv+")")
+}
+
+func (f *ConnFlags) TLSConfig() (*tls.Config, error) {
+	config := &tls.Config{ServerName: f.ServerName, InsecureSkipVerify: f.InsecureSkipVerify}
+	if f.CAFile != "" {
+		pem, err := os.ReadFile(f.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificates found in " + f.CAFile)
+		}
+	}
+	if f.CertFile != "" || f.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(f.CertFile, f.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+func (f *ConnFlags) Dial() (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if f.TLS || f.CAFile != "" || f.InsecureSkipVerify || f.CertFile != "" {
+		config, err := f.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(config)
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	call := &callCredentials{apiKey: f.APIKey, token: f.Token}
+	if call.apiKey == "" && call.token == "" {
+		call.apiKey, call.token = os.Getenv(APIKeyEnv), os.Getenv(TokenEnv)
+	}
+	if call.apiKey != "" || call.token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(call))
+	}
+	return grpc.Dial(f.Addr, opts...)
+}
+
+// callCredentials sends the API key or the JWT with every call.
+type callCredentials struct {
+	apiKey string
+	token  string
+}
+
+func (c *callCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	if c.token != "" {
+		return map[string]string{"authorization": "Bearer " + c.token}, nil
+	}
+	return map[string]string{"x-api-key": c.apiKey}, nil
+}
+
+// RequireTransportSecurity allows credentials over plain connections to a
+// server on the local network, use -tls elsewhere.
+func (c *callCredentials) RequireTransportSecurity() bool {
+	return false
+}
@@ -0,0 +1,44 @@
+// Package cli holds what the command-line clients share: connecting to the
+// server and formatting songs and player state for a terminal.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"strconv"
+	"strings"
+)
+
+var ErrBadDuration = errors.New("invalid duration, expected seconds or m:ss")
+
+// FormatDuration formats seconds as MM:SS.
+func FormatDuration(seconds uint64) string {
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
+
+// ParseDuration accepts plain seconds ("215") as well as "3:35" and "1:02:03".
+func ParseDuration(text string) (uint64, error) {
+	var total uint64
+	for _, part := range strings.Split(strings.TrimSpace(text), ":") {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return 0, ErrBadDuration
+		}
+		total = total*60 + n
+	}
+	return total, nil
+}
+
+// FormatPlayerInfo renders a Player message as "MM:SS/MM:SS - Title".
+func FormatPlayerInfo(info *ps.PlayerInfo) string {
+	return fmt.Sprintf("%s/%s - %s", FormatDuration(info.GetElapsed()), FormatDuration(info.GetDuration()), info.GetTitle())
+}
+
+// SongTitle prefixes the title with the artist when it is known.
+func SongTitle(song *ps.SongInfo) string {
+	if song.GetArtist() != "" {
+		return song.GetArtist() + " - " + song.GetTitle()
+	}
+	return song.GetTitle()
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	ps "github.com/sgoldenf/playlist/api"
+	"testing"
+)
+
+func TestFormatDuration(t *testing.T) {
+	tests := []struct {
+		in   uint64
+		want string
+	}{
+		{0, "00:00"},
+		{9, "00:09"},
+		{215, "03:35"},
+		{3723, "62:03"},
+	}
+	for _, test := range tests {
+		if got := FormatDuration(test.in); got != test.want {
+			t.Errorf("Out -> \nWant: %v\nGot : %v", test.want, got)
+		}
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    uint64
+		wantErr bool
+	}{
+		{"215", 215, false},
+		{"3:35", 215, false},
+		{" 1:02:03 ", 3723, false},
+		{"", 0, true},
+		{"3:", 0, true},
+		{"-5", 0, true},
+		{"abc", 0, true},
+	}
+	for _, test := range tests {
+		got, err := ParseDuration(test.in)
+		if (err != nil) != test.wantErr || got != test.want {
+			t.Errorf("Out -> \nWant: %v %v\nGot : %v %v", test.want, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestFormatPlayerInfo(t *testing.T) {
+	info := &ps.PlayerInfo{Title: "Song", Duration: 215, Elapsed: 61}
+	if got, want := FormatPlayerInfo(info), "01:01/03:35 - Song"; got != want {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+	}
+	song := &ps.SongInfo{Title: "Song", Artist: "Band"}
+	if got, want := SongTitle(song), "Band - Song"; got != want {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+	}
+}
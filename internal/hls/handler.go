@@ -0,0 +1,94 @@
+package hls
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/stream"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	SongPathPrefix = "/v1/hls/songs/"
+	PlaylistName   = "index.m3u8"
+	LivePath       = "/v1/hls/live/" + PlaylistName
+	liveTTL        = time.Hour
+)
+
+// SongURL returns a signed address of the media playlist of a song. Segments
+// inside the playlist point to the stream URL signed with the same parameters.
+func SongURL(signer *stream.Signer, id string, ttl time.Duration) (string, time.Time) {
+	query, expires := signer.Query(id, ttl)
+	return signer.BaseURL + SongPathPrefix + url.PathEscape(id) + "/" + PlaylistName + "?" + query.Encode(), expires
+}
+
+func streamURI(id string, query url.Values) string {
+	return stream.PathPrefix + url.PathEscape(id) + "?" + query.Encode()
+}
+
+func writePlaylist(c *gin.Context, p *MediaPlaylist, cacheControl string) {
+	c.Header("Content-Type", ContentType)
+	c.Header("Cache-Control", cacheControl)
+	c.Status(http.StatusOK)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	_, _ = p.WriteTo(c.Writer)
+}
+
+// SongHandler serves SongPathPrefix+":id/"+PlaylistName after checking the URL
+// signature. Only files accepted by contains are segmented.
+func SongHandler(signer *stream.Signer, segmenter *Segmenter, lookup func(ctx context.Context, id string) (*ps.SongInfo, error), contains func(path string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		if err := signer.Verify(id, c.Query("expires"), c.Query("sig")); err != nil {
+			c.String(http.StatusForbidden, err.Error())
+			return
+		}
+		song, err := lookup(c.Request.Context(), id)
+		if err != nil {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		if song.GetPath() == "" {
+			c.String(http.StatusNotFound, stream.ErrNoFile.Error())
+			return
+		}
+		if contains == nil || !contains(song.Path) {
+			c.String(http.StatusForbidden, stream.ErrOutsideLibrary.Error())
+			return
+		}
+		segments, err := segmenter.Segments(song.Path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			c.String(http.StatusNotFound, stream.ErrNoFile.Error())
+			return
+		case err != nil:
+			c.String(http.StatusUnsupportedMediaType, err.Error())
+			return
+		}
+		query := url.Values{"expires": {c.Query("expires")}, "sig": {c.Query("sig")}}
+		uri := streamURI(id, query)
+		p := &MediaPlaylist{VOD: true}
+		for _, s := range segments {
+			p.Entries = append(p.Entries, Entry{Segment: s, URI: uri})
+		}
+		writePlaylist(c, p, "private, max-age=300")
+	}
+}
+
+// LiveHandler serves the live playlist of the server-side player. Segment URIs
+// are signed stream URLs valid for an hour.
+func LiveHandler(live *Live, signer *stream.Signer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		p := live.Playlist(func(id string) string {
+			query, _ := signer.Query(id, liveTTL)
+			return streamURI(id, query)
+		})
+		writePlaylist(c, p, "no-cache")
+	}
+}
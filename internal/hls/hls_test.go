@@ -0,0 +1,218 @@
+package hls
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/stream"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writeMP3 writes frames of 128 kbit/s 44.1 kHz audio, 417 bytes and 1152
+// samples each, after a 10 byte ID3v2 header.
+func writeMP3(t *testing.T, path string, frames int) {
+	var b bytes.Buffer
+	b.Write([]byte{'I', 'D', '3', 4, 0, 0, 0, 0, 0, 0})
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xff, 0xfb, 0x90, 0x00})
+		b.Write(frame)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "song.mp3")
+	writeMP3(t, path, 500)
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	stat, _ := f.Stat()
+	segments, err := Split(f, stat.Size(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 6 seconds need 230 frames of 26.12 ms.
+	frame := time.Second * 1152 / 44100
+	expected := []Segment{
+		{Offset: 10, Length: 230 * 417, Start: 0, Duration: 230 * 1152 * time.Second / 44100},
+		{Offset: 10 + 230*417, Length: 230 * 417, Start: 230 * 1152 * time.Second / 44100, Duration: 460*1152*time.Second/44100 - 230*1152*time.Second/44100},
+		{Offset: 10 + 460*417, Length: 40 * 417, Start: 460 * 1152 * time.Second / 44100, Duration: 500*1152*time.Second/44100 - 460*1152*time.Second/44100},
+	}
+	if len(segments) != len(expected) {
+		t.Fatalf("Out -> \nWant: %+v\nGot : %+v", expected, segments)
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("Out -> \nWant: %+v\nGot : %+v", expected[i], segments[i])
+		}
+	}
+	if d := segments[0].Duration; d < 6*time.Second || d > 6*time.Second+frame {
+		t.Errorf("first segment is %v long", d)
+	}
+	if _, err = Split(bytes.NewReader([]byte("not audio")), 9, 0); err == nil {
+		t.Errorf("expected error for a file without frames")
+	}
+}
+
+func TestMediaPlaylist_WriteTo(t *testing.T) {
+	p := &MediaPlaylist{
+		Sequence:              7,
+		DiscontinuitySequence: 2,
+		Entries: []Entry{
+			{Segment: Segment{Offset: 10, Length: 100, Duration: 6008 * time.Millisecond}, URI: "/a"},
+			{Segment: Segment{Offset: 0, Length: 50, Duration: 2500 * time.Millisecond}, URI: "/b", Discontinuity: true},
+		},
+	}
+	var b bytes.Buffer
+	if _, err := p.WriteTo(&b); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "#EXTM3U\n#EXT-X-VERSION:4\n#EXT-X-TARGETDURATION:6\n#EXT-X-MEDIA-SEQUENCE:7\n" +
+		"#EXT-X-DISCONTINUITY-SEQUENCE:2\n" +
+		"#EXTINF:6.008,\n#EXT-X-BYTERANGE:100@10\n/a\n" +
+		"#EXT-X-DISCONTINUITY\n#EXTINF:2.500,\n#EXT-X-BYTERANGE:50@0\n/b\n"
+	if b.String() != expected {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", expected, b.String())
+	}
+	p.VOD, p.Sequence, p.DiscontinuitySequence = true, 0, 0
+	b.Reset()
+	_, _ = p.WriteTo(&b)
+	if !strings.Contains(b.String(), "#EXT-X-PLAYLIST-TYPE:VOD\n") || !strings.HasSuffix(b.String(), "#EXT-X-ENDLIST\n") {
+		t.Errorf("unexpected VOD playlist %q", b.String())
+	}
+}
+
+type fakeSource struct {
+	m       sync.Mutex
+	song    *ps.SongInfo
+	elapsed uint64
+}
+
+func (s *fakeSource) Current() (*ps.SongInfo, uint64, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.song, s.elapsed, true
+}
+
+func (s *fakeSource) set(song *ps.SongInfo, elapsed uint64) {
+	s.m.Lock()
+	s.song, s.elapsed = song, elapsed
+	s.m.Unlock()
+}
+
+func TestLive_Playlist(t *testing.T) {
+	dir := t.TempDir()
+	first := &ps.SongInfo{Id: "1", Path: filepath.Join(dir, "1.mp3")}
+	second := &ps.SongInfo{Id: "2", Path: filepath.Join(dir, "2.mp3")}
+	// About 60 seconds each, ten segments.
+	writeMP3(t, first.Path, 2300)
+	writeMP3(t, second.Path, 2300)
+	source := &fakeSource{}
+	live := &Live{Source: source, Segmenter: &Segmenter{}, Window: 3, Contains: func(path string) bool {
+		return filepath.Dir(path) == dir
+	}}
+	uri := func(id string) string { return "/" + id }
+
+	if p := live.Playlist(uri); len(p.Entries) != 0 {
+		t.Errorf("expected empty playlist without a song, got %+v", p)
+	}
+	source.set(first, 13)
+	p := live.Playlist(uri)
+	if len(p.Entries) != 1 || p.Sequence != 0 ||
+		p.Entries[0].Start > 13*time.Second || p.Entries[0].Start+p.Entries[0].Duration <= 13*time.Second {
+		t.Errorf("expected the segment playing at 13s, got %+v", p)
+	}
+	source.set(first, 31)
+	p = live.Playlist(uri)
+	if len(p.Entries) != 3 || p.Sequence != 1 || p.Entries[2].Start > 31*time.Second {
+		t.Errorf("expected a window of three segments up to 31s, got %+v", p)
+	}
+	for i := 1; i < len(p.Entries); i++ {
+		if p.Entries[i].Offset != p.Entries[i-1].Offset+p.Entries[i-1].Length {
+			t.Errorf("segments %d and %d are not contiguous", i-1, i)
+		}
+	}
+
+	source.set(second, 0)
+	p = live.Playlist(uri)
+	last := p.Entries[len(p.Entries)-1]
+	if p.Sequence != 2 || last.URI != "/2" || last.Start != 0 || !last.Discontinuity {
+		t.Errorf("expected the second song after a discontinuity, got %+v", p)
+	}
+	source.set(second, 13)
+	live.Playlist(uri)
+	source.set(second, 19)
+	p = live.Playlist(uri)
+	if p.DiscontinuitySequence != 1 || p.Entries[0].URI != "/2" || p.Entries[0].Discontinuity {
+		t.Errorf("expected discontinuity sequence 1 after the song change left the window, got %+v", p)
+	}
+}
+
+func TestSongHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	songs := map[string]*ps.SongInfo{
+		"mp3":     {Id: "mp3", Path: filepath.Join(dir, "song.mp3")},
+		"wav":     {Id: "wav", Path: filepath.Join(dir, "song.wav")},
+		"outside": {Id: "outside", Path: filepath.Join(t.TempDir(), "song.mp3")},
+	}
+	writeMP3(t, songs["mp3"].Path, 500)
+	writeMP3(t, songs["outside"].Path, 500)
+	signer := stream.NewSigner(nil, "http://music.local")
+	r := gin.New()
+	r.GET(SongPathPrefix+":id/"+PlaylistName, SongHandler(signer, &Segmenter{}, func(_ context.Context, id string) (*ps.SongInfo, error) {
+		if song, ok := songs[id]; ok {
+			return song, nil
+		}
+		return nil, errors.New("song not found")
+	}, func(path string) bool {
+		return filepath.Dir(path) == dir
+	}))
+	get := func(link string) (*http.Response, string) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, link, nil))
+		body, _ := io.ReadAll(w.Result().Body)
+		return w.Result(), string(body)
+	}
+
+	link, _ := SongURL(signer, "mp3", time.Minute)
+	if !strings.HasPrefix(link, "http://music.local/v1/hls/songs/mp3/index.m3u8?") {
+		t.Errorf("unexpected url %q", link)
+	}
+	res, body := get(link)
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != ContentType {
+		t.Fatalf("playlist response -> %d %v %q", res.StatusCode, res.Header, body)
+	}
+	query := link[strings.Index(link, "?"):]
+	if strings.Count(body, "#EXTINF:") != 3 || strings.Count(body, "\n/v1/stream/mp3"+query+"\n") != 3 ||
+		!strings.Contains(body, "#EXT-X-BYTERANGE:95910@10\n") || !strings.HasSuffix(body, "#EXT-X-ENDLIST\n") {
+		t.Errorf("unexpected playlist %q", body)
+	}
+
+	link, _ = SongURL(signer, "wav", time.Minute)
+	if res, _ = get(link); res.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("expected 415 for wav, got %d", res.StatusCode)
+	}
+	link, _ = SongURL(signer, "outside", time.Minute)
+	if res, _ = get(link); res.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for a file outside the library, got %d", res.StatusCode)
+	}
+	if res, _ = get(SongPathPrefix + "mp3/" + PlaylistName + "?expires=9999999999&sig=forged"); res.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for forged signature, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,86 @@
+package hls
+
+import (
+	ps "github.com/sgoldenf/playlist/api"
+	"sync"
+	"time"
+)
+
+const DefaultWindow = 5
+
+type Source interface {
+	Current() (*ps.SongInfo, uint64, bool)
+}
+
+type liveEntry struct {
+	Segment
+	id            string
+	discontinuity bool
+}
+
+// Live is a sliding window playlist that follows the server-side player.
+// Segments of the current song are published once playback reaches them and a
+// discontinuity is marked whenever the song changes or the player jumps.
+type Live struct {
+	Source    Source
+	Segmenter *Segmenter
+	Window    int
+	// Contains reports whether a file may be published; songs it rejects
+	// are left out of the playlist.
+	Contains func(path string) bool
+
+	m               sync.Mutex
+	entries         []liveEntry
+	sequence        uint64
+	discontinuities uint64
+	id              string
+	next            int
+}
+
+func (l *Live) update() {
+	song, elapsed, _ := l.Source.Current()
+	if song == nil || song.Path == "" || !Supported(song.Path) || l.Contains == nil || !l.Contains(song.Path) {
+		return
+	}
+	segments, err := l.Segmenter.Segments(song.Path)
+	if err != nil || len(segments) == 0 {
+		return
+	}
+	window := l.Window
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	i := find(segments, time.Duration(elapsed)*time.Second)
+	jumped := song.Id != l.id || i < l.next-1 || i > l.next+window
+	if jumped {
+		l.id, l.next = song.Id, i
+	}
+	for ; l.next <= i; l.next++ {
+		l.entries = append(l.entries, liveEntry{
+			Segment:       segments[l.next],
+			id:            song.Id,
+			discontinuity: jumped && len(l.entries) > 0,
+		})
+		jumped = false
+	}
+	for len(l.entries) > window {
+		if l.entries[1].discontinuity {
+			l.discontinuities++
+		}
+		l.entries = l.entries[1:]
+		l.sequence++
+	}
+}
+
+// Playlist brings the window up to date with the player and returns it, uri
+// builds the address of the audio file of a song.
+func (l *Live) Playlist(uri func(id string) string) *MediaPlaylist {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.update()
+	p := &MediaPlaylist{Sequence: l.sequence, DiscontinuitySequence: l.discontinuities}
+	for i, e := range l.entries {
+		p.Entries = append(p.Entries, Entry{Segment: e.Segment, URI: uri(e.id), Discontinuity: e.discontinuity && i > 0})
+	}
+	return p
+}
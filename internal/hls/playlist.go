@@ -0,0 +1,88 @@
+package hls
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"time"
+)
+
+const ContentType = "application/vnd.apple.mpegurl"
+
+type Entry struct {
+	Segment
+	URI           string
+	Discontinuity bool
+}
+
+// MediaPlaylist is an HLS media playlist of byte range segments. A VOD
+// playlist lists a whole song and ends with EXT-X-ENDLIST, a live one is a
+// window that clients reload.
+type MediaPlaylist struct {
+	VOD                   bool
+	Sequence              uint64
+	DiscontinuitySequence uint64
+	Entries               []Entry
+}
+
+func roundSeconds(d time.Duration) int {
+	return int(math.Round(d.Seconds()))
+}
+
+func (p *MediaPlaylist) TargetDuration() int {
+	target := roundSeconds(DefaultTargetDuration)
+	if len(p.Entries) > 0 {
+		target = 1
+	}
+	for _, e := range p.Entries {
+		if d := roundSeconds(e.Duration); d > target {
+			target = d
+		}
+	}
+	return target
+}
+
+func (p *MediaPlaylist) WriteTo(w io.Writer) (int64, error) {
+	cw := &countingWriter{w: bufio.NewWriter(w)}
+	fmt.Fprint(cw, "#EXTM3U\n#EXT-X-VERSION:4\n")
+	fmt.Fprintf(cw, "#EXT-X-TARGETDURATION:%d\n", p.TargetDuration())
+	fmt.Fprintf(cw, "#EXT-X-MEDIA-SEQUENCE:%d\n", p.Sequence)
+	if p.DiscontinuitySequence > 0 {
+		fmt.Fprintf(cw, "#EXT-X-DISCONTINUITY-SEQUENCE:%d\n", p.DiscontinuitySequence)
+	}
+	if p.VOD {
+		fmt.Fprint(cw, "#EXT-X-PLAYLIST-TYPE:VOD\n")
+	}
+	for _, e := range p.Entries {
+		if e.Discontinuity {
+			fmt.Fprint(cw, "#EXT-X-DISCONTINUITY\n")
+		}
+		fmt.Fprintf(cw, "#EXTINF:%.3f,\n", e.Duration.Seconds())
+		fmt.Fprintf(cw, "#EXT-X-BYTERANGE:%d@%d\n", e.Length, e.Offset)
+		fmt.Fprintf(cw, "%s\n", e.URI)
+	}
+	if p.VOD {
+		fmt.Fprint(cw, "#EXT-X-ENDLIST\n")
+	}
+	if cw.err != nil {
+		return cw.n, cw.err
+	}
+	return cw.n, cw.w.Flush()
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(b []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+	n, err := cw.w.Write(b)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
@@ -0,0 +1,149 @@
+// Package hls publishes songs as HTTP Live Streaming media playlists. MP3 and
+// AAC (ADTS) files are packed audio that HLS clients play as is, so a song is
+// split into byte ranges on frame boundaries and no transcoding is needed.
+package hls
+
+import (
+	"errors"
+	"github.com/sgoldenf/playlist/internal/audio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultTargetDuration = 6 * time.Second
+	maxCached             = 128
+)
+
+var ErrUnsupported = errors.New("hls: format can not be segmented")
+
+// Segment is a byte range of an audio file that starts on a frame boundary.
+type Segment struct {
+	Offset   int64
+	Length   int64
+	Start    time.Duration
+	Duration time.Duration
+}
+
+func (s Segment) end() time.Duration {
+	return s.Start + s.Duration
+}
+
+func Supported(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mp3", ".aac":
+		return true
+	}
+	return false
+}
+
+// Split cuts the frames of an MP3 or ADTS stream into segments of at least
+// target duration; only the last segment may be shorter.
+func Split(r io.ReaderAt, size int64, target time.Duration) ([]Segment, error) {
+	if target <= 0 {
+		target = DefaultTargetDuration
+	}
+	layout, err := audio.FrameLayout(r, size)
+	if err != nil {
+		return nil, err
+	}
+	var segments []Segment
+	var cur Segment
+	var rate uint32
+	var samples, started uint64
+	at := func(samples uint64) time.Duration {
+		return time.Duration(samples) * time.Second / time.Duration(rate)
+	}
+	err = audio.Frames(r, layout.Start, layout.End, func(f audio.Frame) error {
+		if rate == 0 {
+			rate = f.SampleRate
+			cur.Offset = f.Offset
+		}
+		cur.Length += int64(f.Size)
+		samples += uint64(f.Samples)
+		if at(samples-started) >= target {
+			cur.Start, cur.Duration = at(started), at(samples)-at(started)
+			segments = append(segments, cur)
+			cur = Segment{Offset: f.Offset + int64(f.Size)}
+			started = samples
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rate == 0 {
+		return nil, audio.ErrUnknownFormat
+	}
+	if cur.Length > 0 {
+		cur.Start, cur.Duration = at(started), at(samples)-at(started)
+		segments = append(segments, cur)
+	}
+	return segments, nil
+}
+
+type cachedFile struct {
+	size     int64
+	modTime  time.Time
+	segments []Segment
+}
+
+// Segmenter splits files with Split and remembers the result until the file
+// changes.
+type Segmenter struct {
+	Target time.Duration
+	m      sync.Mutex
+	cache  map[string]cachedFile
+}
+
+func (s *Segmenter) Segments(path string) ([]Segment, error) {
+	if !Supported(path) {
+		return nil, ErrUnsupported
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	s.m.Lock()
+	cached, ok := s.cache[path]
+	s.m.Unlock()
+	if ok && cached.size == stat.Size() && cached.modTime.Equal(stat.ModTime()) {
+		return cached.segments, nil
+	}
+	segments, err := Split(f, stat.Size(), s.Target)
+	if err != nil {
+		return nil, err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.cache == nil {
+		s.cache = make(map[string]cachedFile)
+	}
+	if len(s.cache) >= maxCached {
+		for key := range s.cache {
+			delete(s.cache, key)
+			break
+		}
+	}
+	s.cache[path] = cachedFile{size: stat.Size(), modTime: stat.ModTime(), segments: segments}
+	return segments, nil
+}
+
+// find returns the index of the segment playing at position.
+func find(segments []Segment, position time.Duration) int {
+	for i, s := range segments {
+		if position < s.end() {
+			return i
+		}
+	}
+	return len(segments) - 1
+}
@@ -0,0 +1,120 @@
+package httpserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/grpc/metadata"
+	"net/http"
+	"nhooyr.io/websocket"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	EventsPath       = "/v1/player/events"
+	tickEvent        = "tick"
+	sseRetry         = 3 * time.Second
+	websocketTimeout = 10 * time.Second
+)
+
+// lastEventID reads the id of the last event a reconnecting client has seen
+// from the Last-Event-ID header sent by EventSource or the last_event_id query
+// parameter.
+func lastEventID(c *gin.Context) uint64 {
+	value := c.GetHeader("Last-Event-ID")
+	if value == "" {
+		value = c.Query("last_event_id")
+	}
+	id, _ := strconv.ParseUint(value, 10, 64)
+	return id
+}
+
+// sseStream writes Player events as Server-Sent Events. Track ticks have no
+// id, so they do not move the position a reconnecting client resumes from.
+type sseStream struct {
+	*serverStream
+}
+
+func (s sseStream) SendHeader(metadata.MD) error {
+	s.c.Header("Content-Type", "text/event-stream")
+	s.c.Header("Cache-Control", "no-cache")
+	s.c.Header("X-Accel-Buffering", "no")
+	s.c.Status(http.StatusOK)
+	_, err := fmt.Fprintf(s.c.Writer, "retry: %d\n\n", sseRetry.Milliseconds())
+	s.c.Writer.Flush()
+	return err
+}
+
+func (s sseStream) Send(info *ps.PlayerInfo) error {
+	body, err := marshaler.Marshal(info)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	if info.EventId > 0 {
+		fmt.Fprintf(&b, "id: %d\n", info.EventId)
+	}
+	event := info.Event
+	if event == "" {
+		event = tickEvent
+	}
+	fmt.Fprintf(&b, "event: %s\ndata: %s\n\n", event, body)
+	if _, err = s.c.Writer.WriteString(b.String()); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// websocketStream sends every Player event as a JSON text message.
+type websocketStream struct {
+	*serverStream
+	conn *websocket.Conn
+	ctx  context.Context
+}
+
+func (s websocketStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s websocketStream) Send(info *ps.PlayerInfo) error {
+	body, err := marshaler.Marshal(info)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(s.ctx, websocketTimeout)
+	defer cancel()
+	return s.conn.Write(ctx, websocket.MessageText, body)
+}
+
+// playerEvents serves the Player stream as WebSocket when the client asks for
+// an upgrade and as Server-Sent Events otherwise.
+func playerEvents(service *server.PlaylistService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req := &ps.ConnectRequest{LastEventId: lastEventID(c)}
+		if strings.EqualFold(c.GetHeader("Upgrade"), "websocket") {
+			conn, err := websocket.Accept(c.Writer, c.Request, nil)
+			if err != nil {
+				return
+			}
+			defer conn.Close(websocket.StatusInternalError, "")
+			// Nothing is expected from the client, reading only handles close frames.
+			ctx := conn.CloseRead(c.Request.Context())
+			if err = service.Player(req, websocketStream{serverStream: &serverStream{c: c}, conn: conn, ctx: ctx}); err != nil {
+				conn.Close(websocket.StatusInternalError, err.Error())
+				return
+			}
+			conn.Close(websocket.StatusNormalClosure, "")
+			return
+		}
+		stream := sseStream{&serverStream{c: c}}
+		if err := stream.SendHeader(nil); err != nil {
+			return
+		}
+		_ = service.Player(req, stream)
+	}
+}
@@ -0,0 +1,108 @@
+package httpserver
+
+import (
+	"bufio"
+	"context"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/protobuf/encoding/protojson"
+	"net/http"
+	"nhooyr.io/websocket"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	id    string
+	event string
+	data  string
+}
+
+func readSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	var e sseEvent
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("read event: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && e.event != "":
+			return e
+		case strings.HasPrefix(line, "id: "):
+			e.id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "event: "):
+			e.event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			e.data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func connectSSE(t *testing.T, url, lastEventID string) (*bufio.Reader, func()) {
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("events -> %d %v", res.StatusCode, res.Header)
+	}
+	return bufio.NewReader(res.Body), func() { res.Body.Close() }
+}
+
+// nextSSE skips ticks.
+func nextSSE(t *testing.T, r *bufio.Reader) sseEvent {
+	for {
+		if e := readSSE(t, r); e.event != tickEvent {
+			return e
+		}
+	}
+}
+
+func TestPlayerEvents(t *testing.T) {
+	ts, service := runTestHTTPServer(t)
+
+	r, closeStream := connectSSE(t, ts.URL+EventsPath, "")
+	defer closeStream()
+	service.P.Play()
+	changed := nextSSE(t, r)
+	service.P.Pause()
+	paused := nextSSE(t, r)
+	var info ps.PlayerInfo
+	if changed.event != server.EventTrackChanged || changed.id == "" || protojson.Unmarshal([]byte(changed.data), &info) != nil ||
+		info.Song == nil || strconv.FormatUint(info.EventId, 10) != changed.id {
+		t.Errorf("unexpected event %+v", changed)
+	}
+	if paused.event != server.EventPaused || paused.id == "" {
+		t.Errorf("unexpected event %+v", paused)
+	}
+	closeStream()
+
+	r, closeResumed := connectSSE(t, ts.URL+EventsPath, changed.id)
+	defer closeResumed()
+	if missed := nextSSE(t, r); missed.id != paused.id || missed.event != server.EventPaused {
+		t.Errorf("Out -> \nWant: %+v\nGot : %+v", paused, missed)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http") + EventsPath + "?last_event_id=" + changed.id
+	conn, _, err := websocket.Dial(ctx, wsURL, nil)
+	if err != nil {
+		t.Fatalf("websocket dial error: %v", err)
+	}
+	defer conn.Close(websocket.StatusNormalClosure, "")
+	typ, data, err := conn.Read(ctx)
+	if err != nil || typ != websocket.MessageText || protojson.Unmarshal(data, &info) != nil {
+		t.Fatalf("websocket read -> %v %q: %v", typ, data, err)
+	}
+	if strconv.FormatUint(info.EventId, 10) != paused.id || info.Event != server.EventPaused {
+		t.Errorf("Out -> \nWant: %+v\nGot : %v", paused, &info)
+	}
+}
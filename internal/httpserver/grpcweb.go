@@ -0,0 +1,63 @@
+package httpserver
+
+import (
+	"github.com/improbable-eng/grpc-web/go/grpcweb"
+	"google.golang.org/grpc"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const websocketPingInterval = 30 * time.Second
+
+// originMatcher allows the listed origins, e.g. "https://music.example.com".
+// Without any, no cross-origin request is allowed.
+func originMatcher(origins []string) func(origin string) bool {
+	allowed := make(map[string]struct{}, len(origins))
+	for _, origin := range origins {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			allowed[origin] = struct{}{}
+		}
+	}
+	return func(origin string) bool {
+		_, ok := allowed[origin]
+		return ok
+	}
+}
+
+// websocketOrigin allows websockets from the allowed origins and from pages of
+// the server itself. Browsers always send an Origin with a websocket, so one
+// without it is refused too.
+func websocketOrigin(allowed func(origin string) bool) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return false
+		}
+		if allowed(origin) {
+			return true
+		}
+		u, err := url.Parse(origin)
+		return err == nil && u.Host == r.Host
+	}
+}
+
+// GRPCWeb serves s to browsers over gRPC-Web. Server streams such as Player
+// are also available over websockets for clients without fetch streaming.
+func GRPCWeb(s *grpc.Server, origins []string) http.Handler {
+	allowed := originMatcher(origins)
+	wrapped := grpcweb.WrapServer(s,
+		grpcweb.WithOriginFunc(allowed),
+		grpcweb.WithWebsockets(true),
+		grpcweb.WithWebsocketPingInterval(websocketPingInterval),
+		grpcweb.WithWebsocketOriginFunc(websocketOrigin(allowed)),
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if wrapped.IsGrpcWebRequest(r) || wrapped.IsGrpcWebSocketRequest(r) || wrapped.IsAcceptableGrpcCorsRequest(r) {
+			wrapped.ServeHTTP(w, r)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
@@ -0,0 +1,118 @@
+package httpserver
+
+import (
+	"bytes"
+	"encoding/binary"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestOriginMatcher(t *testing.T) {
+	tests := []struct {
+		origins []string
+		origin  string
+		want    bool
+	}{
+		{[]string{"*"}, "https://evil.example.com", false},
+		{nil, "https://evil.example.com", false},
+		{[]string{"https://music.example.com/"}, "https://music.example.com", true},
+		{[]string{"https://music.example.com"}, "http://music.example.com", false},
+		{[]string{""}, "https://music.example.com", false},
+		{[]string{""}, "", false},
+	}
+	for _, tt := range tests {
+		if got := originMatcher(tt.origins)(tt.origin); got != tt.want {
+			t.Errorf("%v %s -> \nWant: %v\nGot : %v", tt.origins, tt.origin, tt.want, got)
+		}
+	}
+}
+
+func TestWebsocketOrigin(t *testing.T) {
+	allowed := websocketOrigin(originMatcher([]string{"https://music.example.com"}))
+	tests := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://music.example.com", true},
+		{"http://localhost:8081", true},
+		{"https://evil.example.com", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "http://localhost:8081/playlist_service.PlaylistService/Player", nil)
+		if tt.origin != "" {
+			r.Header.Set("Origin", tt.origin)
+		}
+		if got := allowed(r); got != tt.want {
+			t.Errorf("%q -> \nWant: %v\nGot : %v", tt.origin, tt.want, got)
+		}
+	}
+}
+
+func TestGRPCWeb(t *testing.T) {
+	s := grpc.NewServer()
+	service := &server.PlaylistService{P: playlist.NewPlaylist([]*ps.SongInfo{{Id: "1", Title: "So What", Duration: 100}})}
+	ps.RegisterPlaylistServiceServer(s, service)
+	ts := httptest.NewServer(GRPCWeb(s, []string{"https://music.example.com"}))
+	defer ts.Close()
+
+	req, _ := http.NewRequest(http.MethodOptions, ts.URL+"/playlist_service.PlaylistService/Pause", nil)
+	req.Header.Set("Origin", "https://music.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.Header.Get("Access-Control-Allow-Origin") != "https://music.example.com" {
+		t.Errorf("expected allowed origin in preflight response, got %v", res.Header)
+	}
+	req.Header.Set("Origin", "https://evil.example.com")
+	if res, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.Header.Get("Access-Control-Allow-Origin") != "" {
+		t.Errorf("expected no CORS headers for other origin, got %v", res.Header)
+	}
+
+	msg, _ := proto.Marshal(&ps.PauseRequest{})
+	frame := append([]byte{0, 0, 0, 0, 0}, msg...)
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(msg)))
+	req, _ = http.NewRequest(http.MethodPost, ts.URL+"/playlist_service.PlaylistService/Pause", bytes.NewReader(frame))
+	req.Header.Set("Content-Type", "application/grpc-web+proto")
+	req.Header.Set("X-Grpc-Web", "1")
+	if res, err = http.DefaultClient.Do(req); err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || len(body) < 5 || body[0] != 0 {
+		t.Fatalf("pause -> %d %v %q", res.StatusCode, res.Header, body)
+	}
+	size := binary.BigEndian.Uint32(body[1:5])
+	var pause ps.PauseResponse
+	if err = proto.Unmarshal(body[5:5+size], &pause); err != nil || !pause.Success {
+		t.Errorf("unexpected response %v: %v", &pause, err)
+	}
+	if trailer := string(body[5+size:]); !strings.Contains(trailer, "grpc-status: 0") && !strings.Contains(trailer, "grpc-status:0") {
+		t.Errorf("expected OK status in trailer, got %q", trailer)
+	}
+
+	if res, err = http.Get(ts.URL + "/v1/songs"); err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusNotFound {
+		t.Errorf("expected 404 for plain HTTP requests, got %d", res.StatusCode)
+	}
+}
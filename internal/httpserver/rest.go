@@ -0,0 +1,419 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/audio"
+	"github.com/sgoldenf/playlist/internal/hls"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/party"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"io"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const importChunkSize = 64 * 1024
+
+var (
+	marshaler   = protojson.MarshalOptions{EmitUnpopulated: true}
+	unmarshaler = protojson.UnmarshalOptions{DiscardUnknown: true}
+
+	errUnknownMethod = status.Error(codes.NotFound, "unknown method")
+)
+
+// httpStatus maps errors of the gRPC handlers, most of which carry no status
+// code, to HTTP status codes.
+func httpStatus(err error) int {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	switch {
+	case errors.Is(err, party.ErrRateLimited):
+		return http.StatusTooManyRequests
+	case errors.Is(err, server.ErrOutsideLibrary):
+		return http.StatusForbidden
+	case errors.Is(err, playlist.ErrSongIsPlaying), errors.Is(err, library.ErrScanRunning),
+		errors.Is(err, party.ErrDisabled), errors.Is(err, party.ErrQueued):
+		return http.StatusConflict
+	case errors.Is(err, server.ErrInvalidSong), errors.Is(err, server.ErrUnsupportedFormat),
+		errors.Is(err, audio.ErrUnknownFormat), errors.Is(err, audio.ErrMalformed),
+		errors.Is(err, party.ErrBadVote):
+		return http.StatusBadRequest
+	case errors.Is(err, playlist.ErrSeekOutOfRange), errors.Is(err, playlist.ErrEmptyPlaylist),
+		errors.Is(err, playlist.ErrMoveOutOfRange):
+		return http.StatusBadRequest
+	case errors.Is(err, server.ErrSongNotFound), errors.Is(err, server.ErrNoSongs),
+		errors.Is(err, server.ErrNoAudioFile), errors.Is(err, playlist.ErrSongNotFound),
+		errors.Is(err, party.ErrNotQueued), errors.Is(err, fs.ErrNotExist):
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
+
+func writeMessage(c *gin.Context, code int, m proto.Message) {
+	body, err := marshaler.Marshal(m)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Data(code, "application/json", body)
+}
+
+func writeError(c *gin.Context, err error) {
+	s, _ := status.FromError(err)
+	c.JSON(httpStatus(err), gin.H{"code": s.Code(), "message": s.Message()})
+}
+
+// bind decodes the protojson request body into m, an empty body leaves m as is.
+func bind(c *gin.Context, m proto.Message) error {
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return err
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err = unmarshaler.Unmarshal(body, m); err != nil {
+		return status.Error(codes.InvalidArgument, "bad request: "+err.Error())
+	}
+	return nil
+}
+
+// unary adapts a handler of a unary RPC to gin.
+func unary(h func(c *gin.Context) (proto.Message, error)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res, err := h(c)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		writeMessage(c, http.StatusOK, res)
+	}
+}
+
+// serverStream lets the gRPC handlers of streaming RPCs run on top of an HTTP
+// request: messages are written as newline-delimited JSON.
+type serverStream struct {
+	c    *gin.Context
+	sent bool
+}
+
+func (s *serverStream) SetHeader(metadata.MD) error { return nil }
+func (s *serverStream) SetTrailer(metadata.MD)      {}
+func (s *serverStream) Context() context.Context    { return s.c.Request.Context() }
+func (s *serverStream) RecvMsg(interface{}) error   { return io.EOF }
+
+// SendHeader starts the response, after that errors are reported in the body.
+func (s *serverStream) SendHeader(metadata.MD) error {
+	if !s.sent {
+		s.sent = true
+		s.c.Header("Content-Type", "application/x-ndjson")
+		s.c.Header("Cache-Control", "no-cache")
+		s.c.Status(http.StatusOK)
+		s.c.Writer.Flush()
+	}
+	return nil
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	body, err := marshaler.Marshal(m.(proto.Message))
+	if err != nil {
+		return err
+	}
+	_ = s.SendHeader(nil)
+	if _, err = s.c.Writer.Write(append(body, '\n')); err != nil {
+		return err
+	}
+	s.c.Writer.Flush()
+	return nil
+}
+
+// finish reports err either as the status of the response or, once messages
+// were sent, as a last line of the stream.
+func (s *serverStream) finish(err error) {
+	if err == nil {
+		if !s.sent {
+			s.c.Status(http.StatusOK)
+		}
+		return
+	}
+	if !s.sent {
+		writeError(s.c, err)
+		return
+	}
+	st, _ := status.FromError(err)
+	_ = s.SendMsg(st.Proto())
+}
+
+type playerStream struct{ *serverStream }
+
+func (s playerStream) Send(m *ps.PlayerInfo) error { return s.SendMsg(m) }
+
+type scanStream struct{ *serverStream }
+
+func (s scanStream) Send(m *ps.ScanProgress) error { return s.SendMsg(m) }
+
+// importStream feeds the request body to ImportSongs in chunks.
+type importStream struct {
+	*serverStream
+	format string
+	dryRun bool
+	body   io.Reader
+	result *ps.ImportSongsResponse
+}
+
+func (s *importStream) Recv() (*ps.ImportSongsRequest, error) {
+	data := make([]byte, importChunkSize)
+	n, err := io.ReadFull(s.body, data)
+	if n == 0 {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return nil, err
+	}
+	return &ps.ImportSongsRequest{Format: s.format, DryRun: s.dryRun, Data: data[:n]}, nil
+}
+
+func (s *importStream) SendAndClose(m *ps.ImportSongsResponse) error {
+	s.result = m
+	return nil
+}
+
+// restMethod returns the PlaylistService method a request of the API calls,
+// so that HTTP requests need the same roles as gRPC calls.
+func restMethod(c *gin.Context) string {
+	custom := c.Param("method")
+	switch c.Request.Method + " " + c.FullPath() {
+	case "GET /v1/songs", "HEAD /v1/songs":
+		return "GetSongs"
+	case "POST /v1/songs":
+		return "CreateSong"
+	case "GET /v1/songs/:id":
+		if _, method, _ := strings.Cut(c.Param("id"), ":"); method == "streamURL" {
+			return "GetStreamURL"
+		}
+		return "GetSong"
+	case "PATCH /v1/songs/:id":
+		return "UpdateSong"
+	case "DELETE /v1/songs/:id":
+		return "DeleteSong"
+	case "POST /v1/songs:method":
+		switch custom {
+		case ":fromFile":
+			return "CreateSongFromFile"
+		case ":batchCreate":
+			return "BatchCreateSongs"
+		case ":batchDelete":
+			return "BatchDeleteSongs"
+		case ":import":
+			return "ImportSongs"
+		}
+	case "POST /v1/player:method":
+		switch custom {
+		case ":play":
+			return "Play"
+		case ":pause":
+			return "Pause"
+		case ":next":
+			return "Next"
+		case ":prev":
+			return "Prev"
+		case ":seek":
+			return "Seek"
+		}
+	case "GET /v1/player/state":
+		return "GetPlayerState"
+	case "GET /v1/player", "GET " + EventsPath:
+		return "Player"
+	case "GET /v1/playlist":
+		return "GetPlaylist"
+	case "POST /v1/playlist:method":
+		return "MoveSong"
+	case "POST /v1/library:method":
+		return "ScanLibrary"
+	case "GET /v1/party":
+		return "GetPartyQueue"
+	case "POST /v1/party:method":
+		switch custom {
+		case ":suggest":
+			return "SuggestSong"
+		case ":vote":
+			return "VoteSong"
+		}
+	case "GET /radio", "HEAD /radio", "GET " + hls.LivePath, "HEAD " + hls.LivePath:
+		// Live audio needs the same role as the stream URLs of songs.
+		return "GetStreamURL"
+	}
+	// Unknown custom methods are answered with 404 by the handlers, the
+	// role check must not reveal more to callers without a role.
+	return "GetSongs"
+}
+
+// registerREST mirrors PlaylistService over HTTP/JSON. Custom methods follow
+// the Google API style, e.g. POST /v1/player:play.
+func registerREST(r gin.IRoutes, service *server.PlaylistService) {
+	r.GET("/v1/songs", unary(func(c *gin.Context) (proto.Message, error) {
+		return service.GetSongs(c.Request.Context(), &ps.ReadSongsRequest{})
+	}))
+	r.POST("/v1/songs", unary(func(c *gin.Context) (proto.Message, error) {
+		song := &ps.SongInfo{}
+		if err := bind(c, song); err != nil {
+			return nil, err
+		}
+		return service.CreateSong(c.Request.Context(), &ps.CreateSongRequest{Song: song})
+	}))
+	r.GET("/v1/songs/:id", unary(func(c *gin.Context) (proto.Message, error) {
+		id, method, _ := strings.Cut(c.Param("id"), ":")
+		switch method {
+		case "":
+			return service.GetSong(c.Request.Context(), &ps.ReadSongRequest{Id: id})
+		case "streamURL":
+			ttl, _ := strconv.ParseUint(c.Query("ttl"), 10, 64)
+			return service.GetStreamURL(c.Request.Context(), &ps.GetStreamURLRequest{Id: id, Ttl: ttl})
+		}
+		return nil, errUnknownMethod
+	}))
+	r.PATCH("/v1/songs/:id", unary(func(c *gin.Context) (proto.Message, error) {
+		song := &ps.SongInfo{}
+		if err := bind(c, song); err != nil {
+			return nil, err
+		}
+		song.Id = c.Param("id")
+		return service.UpdateSong(c.Request.Context(), &ps.UpdateSongRequest{Song: song})
+	}))
+	r.DELETE("/v1/songs/:id", unary(func(c *gin.Context) (proto.Message, error) {
+		return service.DeleteSong(c.Request.Context(), &ps.DeleteSongRequest{Id: c.Param("id")})
+	}))
+	r.POST("/v1/songs:method", func(c *gin.Context) {
+		switch c.Param("method") {
+		case ":fromFile":
+			unary(func(c *gin.Context) (proto.Message, error) {
+				req := &ps.CreateSongFromFileRequest{}
+				if err := bind(c, req); err != nil {
+					return nil, err
+				}
+				return service.CreateSongFromFile(c.Request.Context(), req)
+			})(c)
+		case ":batchCreate":
+			unary(func(c *gin.Context) (proto.Message, error) {
+				req := &ps.BatchCreateSongsRequest{}
+				if err := bind(c, req); err != nil {
+					return nil, err
+				}
+				return service.BatchCreateSongs(c.Request.Context(), req)
+			})(c)
+		case ":batchDelete":
+			unary(func(c *gin.Context) (proto.Message, error) {
+				req := &ps.BatchDeleteSongsRequest{}
+				if err := bind(c, req); err != nil {
+					return nil, err
+				}
+				return service.BatchDeleteSongs(c.Request.Context(), req)
+			})(c)
+		case ":import":
+			dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+			stream := &importStream{serverStream: &serverStream{c: c}, format: c.Query("format"), dryRun: dryRun, body: c.Request.Body}
+			if err := service.ImportSongs(stream); err != nil {
+				writeError(c, err)
+				return
+			}
+			writeMessage(c, http.StatusOK, stream.result)
+		default:
+			writeError(c, errUnknownMethod)
+		}
+	})
+	r.POST("/v1/player:method", unary(func(c *gin.Context) (proto.Message, error) {
+		switch c.Param("method") {
+		case ":play":
+			return service.Play(c.Request.Context(), &ps.PlayRequest{})
+		case ":pause":
+			return service.Pause(c.Request.Context(), &ps.PauseRequest{})
+		case ":next":
+			return service.Next(c.Request.Context(), &ps.NextSongRequest{})
+		case ":prev":
+			return service.Prev(c.Request.Context(), &ps.PrevSongRequest{})
+		case ":seek":
+			req := &ps.SeekRequest{}
+			if err := bind(c, req); err != nil {
+				return nil, err
+			}
+			return service.Seek(c.Request.Context(), req)
+		}
+		return nil, errUnknownMethod
+	}))
+	r.GET("/v1/player/state", unary(func(c *gin.Context) (proto.Message, error) {
+		return service.GetPlayerState(c.Request.Context(), &ps.GetPlayerStateRequest{})
+	}))
+	r.GET("/v1/player", func(c *gin.Context) {
+		stream := &serverStream{c: c}
+		_ = stream.SendHeader(nil)
+		stream.finish(service.Player(&ps.ConnectRequest{LastEventId: lastEventID(c)}, playerStream{stream}))
+	})
+	r.GET("/v1/playlist", unary(func(c *gin.Context) (proto.Message, error) {
+		return service.GetPlaylist(c.Request.Context(), &ps.GetPlaylistRequest{})
+	}))
+	r.POST("/v1/playlist:method", unary(func(c *gin.Context) (proto.Message, error) {
+		if c.Param("method") != ":move" {
+			return nil, errUnknownMethod
+		}
+		req := &ps.MoveSongRequest{}
+		if err := bind(c, req); err != nil {
+			return nil, err
+		}
+		return service.MoveSong(c.Request.Context(), req)
+	}))
+	r.GET("/v1/party", unary(func(c *gin.Context) (proto.Message, error) {
+		return service.GetPartyQueue(c.Request.Context(), &ps.GetPartyQueueRequest{})
+	}))
+	r.POST("/v1/party:method", unary(func(c *gin.Context) (proto.Message, error) {
+		switch c.Param("method") {
+		case ":suggest":
+			req := &ps.SuggestSongRequest{}
+			if err := bind(c, req); err != nil {
+				return nil, err
+			}
+			return service.SuggestSong(c.Request.Context(), req)
+		case ":vote":
+			req := &ps.VoteSongRequest{}
+			if err := bind(c, req); err != nil {
+				return nil, err
+			}
+			return service.VoteSong(c.Request.Context(), req)
+		}
+		return nil, errUnknownMethod
+	}))
+	r.POST("/v1/library:method", func(c *gin.Context) {
+		if c.Param("method") != ":scan" {
+			writeError(c, errUnknownMethod)
+			return
+		}
+		stream := &serverStream{c: c}
+		stream.finish(service.ScanLibrary(&ps.ScanLibraryRequest{}, scanStream{stream}))
+	})
+}
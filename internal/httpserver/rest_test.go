@@ -0,0 +1,394 @@
+package httpserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/party"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func runTestHTTPServer(t *testing.T) (*httptest.Server, *server.PlaylistService) {
+	gin.SetMode(gin.TestMode)
+	service, err := server.NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	ts := httptest.NewServer(New(service))
+	t.Cleanup(ts.Close)
+	return ts, service
+}
+
+func doJSON(t *testing.T, method, url, body string) (int, []byte) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	data, _ := io.ReadAll(res.Body)
+	return res.StatusCode, data
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code int
+	}{
+		{status.Error(codes.InvalidArgument, "bad"), http.StatusBadRequest},
+		{status.Error(codes.PermissionDenied, "denied"), http.StatusForbidden},
+		{server.ErrSongNotFound, http.StatusNotFound},
+		{server.ErrNoAudioFile, http.StatusNotFound},
+		{fmt.Errorf("batch delete error: %s: %w", "uuid1", server.ErrSongNotFound), http.StatusNotFound},
+		{errors.New("playlist not found"), http.StatusInternalServerError},
+		{playlist.ErrSongIsPlaying, http.StatusConflict},
+		{playlist.ErrMoveOutOfRange, http.StatusBadRequest},
+		{playlist.ErrSongNotFound, http.StatusNotFound},
+		{fmt.Errorf("batch create error: song %d: %w", 1, server.ErrInvalidSong), http.StatusBadRequest},
+		{fmt.Errorf("batch create error: %w", errors.New("connection refused")), http.StatusInternalServerError},
+		{fmt.Errorf("%w %q", server.ErrUnsupportedFormat, "xml"), http.StatusBadRequest},
+		{server.ErrOutsideLibrary, http.StatusForbidden},
+		{errors.New("song creation unsuccessful"), http.StatusInternalServerError},
+		{party.ErrRateLimited, http.StatusTooManyRequests},
+		{party.ErrQueued, http.StatusConflict},
+		{party.ErrNotQueued, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if code := httpStatus(tt.err); code != tt.code {
+			t.Errorf("%v -> \nWant: %v\nGot : %v", tt.err, tt.code, code)
+		}
+	}
+}
+
+func TestREST_Songs(t *testing.T) {
+	ts, _ := runTestHTTPServer(t)
+	title := "REST " + uuid.New().String()
+
+	code, body := doJSON(t, http.MethodPost, ts.URL+"/v1/songs", `{"title": "`+title+`", "duration": 215, "artist": "Dave Brubeck"}`)
+	var created ps.CreateSongResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &created) != nil || created.Song.GetId() == "" {
+		t.Fatalf("create -> %d %s", code, body)
+	}
+	id := created.Song.Id
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/songs/"+id, "")
+	var read ps.ReadSongResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &read) != nil || read.Song.GetTitle() != title || read.Song.GetArtist() != "Dave Brubeck" {
+		t.Errorf("get -> %d %s", code, body)
+	}
+
+	code, body = doJSON(t, http.MethodPatch, ts.URL+"/v1/songs/"+id, `{"album": "Time Out"}`)
+	if code != http.StatusOK {
+		t.Errorf("patch -> %d %s", code, body)
+	}
+	_, body = doJSON(t, http.MethodGet, ts.URL+"/v1/songs/"+id, "")
+	if protojson.Unmarshal(body, &read) != nil || read.Song.GetAlbum() != "Time Out" || read.Song.GetTitle() != title {
+		t.Errorf("expected album after patch, got %s", body)
+	}
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/songs", "")
+	if code != http.StatusOK || !strings.Contains(string(body), id) {
+		t.Errorf("list -> %d %s", code, body)
+	}
+
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/songs/"+id+":streamURL", "")
+	if code != http.StatusNotFound || !strings.Contains(string(body), "song has no audio file") {
+		t.Errorf("stream url -> %d %s", code, body)
+	}
+
+	code, body = doJSON(t, http.MethodDelete, ts.URL+"/v1/songs/"+id, "")
+	var deleted ps.DeleteSongResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &deleted) != nil || !deleted.Success {
+		t.Errorf("delete -> %d %s", code, body)
+	}
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/songs/"+id, "")
+	var apiErr struct {
+		Code    codes.Code
+		Message string
+	}
+	if code != http.StatusNotFound || json.Unmarshal(body, &apiErr) != nil || apiErr.Message != "song not found" {
+		t.Errorf("get deleted -> %d %s", code, body)
+	}
+
+	for _, tt := range []struct {
+		method, path, body string
+		code               int
+	}{
+		{http.MethodPost, "/v1/songs", `{"title": ""}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/songs", `{"title": 5}`, http.StatusBadRequest},
+		{http.MethodPost, "/v1/songs:unknown", ``, http.StatusNotFound},
+		{http.MethodPost, "/v1/player:rewind", ``, http.StatusNotFound},
+	} {
+		if code, body = doJSON(t, tt.method, ts.URL+tt.path, tt.body); code != tt.code {
+			t.Errorf("%s %s -> \nWant: %d\nGot : %d %s", tt.method, tt.path, tt.code, code, body)
+		}
+	}
+}
+
+func TestREST_BatchAndImport(t *testing.T) {
+	ts, _ := runTestHTTPServer(t)
+	title := "REST " + uuid.New().String()
+
+	code, body := doJSON(t, http.MethodPost, ts.URL+"/v1/songs:batchCreate",
+		`{"songs": [{"title": "`+title+` 1", "duration": 100}, {"title": "`+title+` 2", "duration": 200}]}`)
+	var batch ps.BatchCreateSongsResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &batch) != nil || len(batch.Results) != 2 {
+		t.Fatalf("batch create -> %d %s", code, body)
+	}
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/songs:import?format=csv&dry_run=true",
+		"title,duration\n"+title+" 1,100\n"+title+" 3,300\n,5\n")
+	var report ps.ImportSongsResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &report) != nil ||
+		report.Total != 3 || report.Created != 1 || report.Duplicates != 1 || report.Invalid != 1 || !report.DryRun {
+		t.Errorf("import -> %d %s", code, body)
+	}
+
+	ids := `"` + batch.Results[0].Song.Id + `", "` + batch.Results[1].Song.Id + `"`
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/songs:batchDelete", `{"ids": [`+ids+`], "allOrNothing": true}`)
+	var removed ps.BatchDeleteSongsResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &removed) != nil || len(removed.Results) != 2 ||
+		!removed.Results[0].Success || !removed.Results[1].Success {
+		t.Errorf("batch delete -> %d %s", code, body)
+	}
+}
+
+func TestREST_Player(t *testing.T) {
+	ts, service := runTestHTTPServer(t)
+
+	res, err := http.Get(ts.URL + "/v1/player")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK || res.Header.Get("Content-Type") != "application/x-ndjson" {
+		t.Fatalf("player -> %d %v", res.StatusCode, res.Header)
+	}
+
+	code, body := doJSON(t, http.MethodPost, ts.URL+"/v1/player:play", "")
+	var played ps.PlayResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &played) != nil || !played.Success {
+		t.Errorf("play -> %d %s", code, body)
+	}
+	defer service.P.Pause()
+
+	line, err := bufio.NewReader(res.Body).ReadBytes('\n')
+	var info ps.PlayerInfo
+	if err != nil || protojson.Unmarshal(line, &info) != nil || info.Title == "" {
+		t.Errorf("expected player info line, got %q: %v", line, err)
+	}
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/player:seek", `{"position": 1}`)
+	var seek ps.SeekResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &seek) != nil || !seek.Success {
+		t.Errorf("seek -> %d %s", code, body)
+	}
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/player/state", "")
+	var state ps.PlayerState
+	if code != http.StatusOK || protojson.Unmarshal(body, &state) != nil || !state.Playing || state.Song == nil || state.Elapsed < 1 {
+		t.Errorf("state -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/player:seek", `{"position": 100000}`); code != http.StatusBadRequest {
+		t.Errorf("seek past the end -> %d %s", code, body)
+	}
+
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/player:pause", "")
+	var paused ps.PauseResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &paused) != nil || !paused.Success || service.P.IsPlaying {
+		t.Errorf("pause -> %d %s", code, body)
+	}
+}
+
+func TestREST_Playlist(t *testing.T) {
+	ts, _ := runTestHTTPServer(t)
+
+	code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/playlist", "")
+	var list ps.GetPlaylistResponse
+	if code != http.StatusOK || protojson.Unmarshal(body, &list) != nil {
+		t.Fatalf("playlist -> %d %s", code, body)
+	}
+	if len(list.Songs) < 2 {
+		t.Skip("need at least two songs in the database")
+	}
+	id := list.Songs[0].Id
+	code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/playlist:move", `{"id": "`+id+`", "position": 1}`)
+	if code != http.StatusOK {
+		t.Errorf("move -> %d %s", code, body)
+	}
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/playlist", "")
+	if code != http.StatusOK || protojson.Unmarshal(body, &list) != nil || list.Songs[1].Id != id {
+		t.Errorf("playlist after move -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/playlist:move", `{"id": "`+id+`", "position": 100000}`); code != http.StatusBadRequest {
+		t.Errorf("move out of range -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/playlist:move", `{"id": "unknown"}`); code != http.StatusNotFound {
+		t.Errorf("move unknown song -> %d %s", code, body)
+	}
+}
+
+func TestREST_Party(t *testing.T) {
+	ts, service := runTestHTTPServer(t)
+
+	if code, body := doJSON(t, http.MethodGet, ts.URL+"/v1/party", ""); code != http.StatusConflict {
+		t.Errorf("party queue without party mode -> %d %s", code, body)
+	}
+	service.EnableParty(party.NewQueue(party.Limit{Count: 1, Window: time.Minute}, party.Limit{}))
+	songs := service.P.Songs()
+	if len(songs) < 2 {
+		t.Skip("need at least two songs in the database")
+	}
+	code, body := doJSON(t, http.MethodPost, ts.URL+"/v1/party:suggest", `{"id": "`+songs[1].Id+`"}`)
+	var queue ps.PartyQueue
+	if code != http.StatusOK || protojson.Unmarshal(body, &queue) != nil || len(queue.Entries) != 1 || queue.Entries[0].Vote != 1 {
+		t.Fatalf("suggest -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/party:suggest", `{"id": "`+songs[0].Id+`"}`); code != http.StatusTooManyRequests {
+		t.Errorf("second suggestion -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/party:vote", `{"id": "`+songs[1].Id+`", "vote": -1}`); code != http.StatusOK {
+		t.Errorf("vote -> %d %s", code, body)
+	}
+	code, body = doJSON(t, http.MethodGet, ts.URL+"/v1/party", "")
+	if code != http.StatusOK || protojson.Unmarshal(body, &queue) != nil || len(queue.Entries) != 0 {
+		t.Errorf("party queue after downvote -> %d %s", code, body)
+	}
+	if code, body = doJSON(t, http.MethodPost, ts.URL+"/v1/party:vote", `{"id": "`+songs[1].Id+`", "vote": 1}`); code != http.StatusNotFound {
+		t.Errorf("vote for a removed suggestion -> %d %s", code, body)
+	}
+}
+
+func TestWebUI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := New(&server.PlaylistService{})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusFound || w.Header().Get("Location") != "/ui/" {
+		t.Errorf("/ -> %d %v", w.Code, w.Header())
+	}
+	for path, want := range map[string]string{
+		"/ui/":       `<script src="app.js">`,
+		"/ui/app.js": "/v1/player/events",
+	} {
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), want) {
+			t.Errorf("%s -> %d, expected %q in body", path, w.Code, want)
+		}
+	}
+}
+
+func TestREST_Auth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, err := server.NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	service.Auth = &auth.Authenticator{}
+	service.Auth.AddAPIKey("alice", "k-alice")
+	ts := httptest.NewServer(New(service))
+	defer ts.Close()
+
+	get := func(path string, header http.Header) int {
+		req, _ := http.NewRequest(http.MethodGet, ts.URL+path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+	withKey := http.Header{"X-Api-Key": {"k-alice"}}
+	tests := []struct {
+		path   string
+		header http.Header
+		want   int
+	}{
+		{"/v1/songs", nil, http.StatusUnauthorized},
+		{"/v1/songs", http.Header{"Authorization": {"Bearer k-eve"}}, http.StatusUnauthorized},
+		{"/v1/songs", withKey, http.StatusOK},
+		{"/v1/player/state?access_token=k-alice", nil, http.StatusOK},
+		{"/v1/playlist", nil, http.StatusUnauthorized},
+		{"/radio", nil, http.StatusUnauthorized},
+		{"/ui/", nil, http.StatusOK},
+		{"/v1/stream/unknown?expires=1&sig=x", nil, http.StatusForbidden},
+	}
+	for _, test := range tests {
+		if got := get(test.path, test.header); got != test.want {
+			t.Errorf("%s %v -> \nWant: %v\nGot : %v", test.path, test.header, test.want, got)
+		}
+	}
+
+	service.Auth.SetPolicy(&auth.Policy{Subjects: map[string]auth.Role{"alice": auth.RoleViewer}})
+	if got := get("/v1/playlist", withKey); got != http.StatusOK {
+		t.Errorf("GetPlaylist as viewer -> %v", got)
+	}
+	if got := get("/radio", withKey); got != http.StatusForbidden {
+		t.Errorf("radio as viewer -> %v", got)
+	}
+}
+
+func TestRESTMethod(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var got string
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		got = restMethod(c)
+		c.AbortWithStatus(http.StatusNoContent)
+	})
+	registerREST(r, &server.PlaylistService{})
+	r.GET(EventsPath, func(*gin.Context) {})
+	tests := []struct {
+		method, path, want string
+	}{
+		{http.MethodGet, "/v1/songs", "GetSongs"},
+		{http.MethodPost, "/v1/songs", "CreateSong"},
+		{http.MethodGet, "/v1/songs/42", "GetSong"},
+		{http.MethodGet, "/v1/songs/42:streamURL", "GetStreamURL"},
+		{http.MethodPatch, "/v1/songs/42", "UpdateSong"},
+		{http.MethodDelete, "/v1/songs/42", "DeleteSong"},
+		{http.MethodPost, "/v1/songs:fromFile", "CreateSongFromFile"},
+		{http.MethodPost, "/v1/songs:batchCreate", "BatchCreateSongs"},
+		{http.MethodPost, "/v1/songs:batchDelete", "BatchDeleteSongs"},
+		{http.MethodPost, "/v1/songs:import", "ImportSongs"},
+		{http.MethodPost, "/v1/player:next", "Next"},
+		{http.MethodPost, "/v1/player:seek", "Seek"},
+		{http.MethodGet, "/v1/player/state", "GetPlayerState"},
+		{http.MethodGet, "/v1/player", "Player"},
+		{http.MethodGet, EventsPath, "Player"},
+		{http.MethodGet, "/v1/playlist", "GetPlaylist"},
+		{http.MethodPost, "/v1/playlist:move", "MoveSong"},
+		{http.MethodPost, "/v1/library:scan", "ScanLibrary"},
+		{http.MethodGet, "/v1/party", "GetPartyQueue"},
+		{http.MethodPost, "/v1/party:suggest", "SuggestSong"},
+		{http.MethodPost, "/v1/party:vote", "VoteSong"},
+	}
+	for _, test := range tests {
+		got = ""
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(test.method, test.path, nil))
+		if got != test.want {
+			t.Errorf("%s %s -> \nWant: %v\nGot : %v", test.method, test.path, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package httpserver exposes the playlist service over plain HTTP.
+package httpserver
+
+import (
+	"context"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/hls"
+	"github.com/sgoldenf/playlist/internal/logging"
+	"github.com/sgoldenf/playlist/internal/radio"
+	"github.com/sgoldenf/playlist/internal/server"
+	"github.com/sgoldenf/playlist/internal/stream"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"github.com/sgoldenf/playlist/internal/webui"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"golang.org/x/exp/slog"
+	"net/http"
+)
+
+func New(service *server.PlaylistService) *gin.Engine {
+	r := gin.New()
+	r.Use(gin.Recovery(), otelgin.Middleware(tracing.ServiceName), logging.Middleware(slog.Default()))
+	lookup := func(ctx context.Context, id string) (*ps.SongInfo, error) {
+		res, err := service.GetSong(ctx, &ps.ReadSongRequest{Id: id})
+		if err != nil {
+			return nil, err
+		}
+		return res.Song, nil
+	}
+	audio := stream.Handler(service.Streams, lookup, service.Scanner.Contains)
+	r.GET(stream.PathPrefix+":id", audio)
+	r.HEAD(stream.PathPrefix+":id", audio)
+	// Everything but the web UI and the signed stream URLs needs credentials.
+	api := r.Group("/", auth.Middleware(service.Auth, restMethod))
+	live := gin.WrapH(&radio.Radio{Source: service.P, Name: "Playlist", Contains: service.Scanner.Contains})
+	api.GET("/radio", live)
+	api.HEAD("/radio", live)
+	segmenter := &hls.Segmenter{}
+	songPlaylist := hls.SongHandler(service.Streams, segmenter, lookup, service.Scanner.Contains)
+	r.GET(hls.SongPathPrefix+":id/"+hls.PlaylistName, songPlaylist)
+	r.HEAD(hls.SongPathPrefix+":id/"+hls.PlaylistName, songPlaylist)
+	livePlaylist := hls.LiveHandler(&hls.Live{Source: service.P, Segmenter: segmenter, Contains: service.Scanner.Contains}, service.Streams)
+	api.GET(hls.LivePath, livePlaylist)
+	api.HEAD(hls.LivePath, livePlaylist)
+	registerREST(api, service)
+	api.GET(EventsPath, playerEvents(service))
+	r.StaticFS(webui.PathPrefix, webui.FS())
+	r.GET("/", func(c *gin.Context) {
+		c.Redirect(http.StatusFound, webui.PathPrefix+"/")
+	})
+	return r
+}
@@ -0,0 +1,390 @@
+// Package library keeps the song store in sync with audio files on disk.
+package library
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/audio"
+	"gorm.io/gorm"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	ActionAdded     = "added"
+	ActionUpdated   = "updated"
+	ActionUnchanged = "unchanged"
+	ActionRemoved   = "removed"
+	ActionFailed    = "failed"
+)
+
+var (
+	ErrScanRunning = errors.New("scan error: scan is already running")
+	// ErrNoDuration keeps files the player could never finish out of the
+	// library.
+	ErrNoDuration = errors.New("scan error: audio file has no duration")
+)
+
+var audioExtensions = map[string]struct{}{
+	".mp3":  {},
+	".flac": {},
+	".ogg":  {},
+	".oga":  {},
+	".opus": {},
+	".wav":  {},
+	".aac":  {},
+}
+
+func IsAudioFile(path string) bool {
+	_, ok := audioExtensions[strings.ToLower(filepath.Ext(path))]
+	return ok
+}
+
+// Playlist receives the songs the scanner adds, updates and removes, usually
+// a *playlist.Playlist.
+type Playlist interface {
+	AddSong(info *ps.SongInfo)
+	UpdateSong(info *ps.SongInfo) bool
+	DeleteSong(id string)
+}
+
+type Scanner struct {
+	DB       *gorm.DB
+	P        Playlist
+	Dirs     []string
+	OnChange func(action string, song *ps.SongInfo)
+	scanning sync.Mutex
+	m        sync.Mutex
+}
+
+func HashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// SongFromFile builds the song record for the file at path. Id is left empty.
+func SongFromFile(path string) (*ps.SongInfo, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := audio.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := HashFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return &ps.SongInfo{
+		Title:    meta.Title,
+		Duration: DurationSeconds(meta.Duration),
+		Artist:   meta.Artist,
+		Album:    meta.Album,
+		Path:     path,
+		Mtime:    stat.ModTime().UnixNano(),
+		Hash:     hash,
+	}, nil
+}
+
+func DurationSeconds(d time.Duration) uint64 {
+	seconds := uint64(d.Round(time.Second) / time.Second)
+	if seconds == 0 && d > 0 {
+		seconds = 1
+	}
+	return seconds
+}
+
+func (sc *Scanner) absDirs() ([]string, error) {
+	if len(sc.Dirs) == 0 {
+		return nil, errors.New("scan error: no music directories configured")
+	}
+	dirs := make([]string, 0, len(sc.Dirs))
+	for _, dir := range sc.Dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, abs)
+	}
+	return dirs, nil
+}
+
+// Contains reports whether path, with symbolic links followed, is inside one
+// of the music directories.
+func (sc *Scanner) Contains(path string) bool {
+	dirs, err := sc.absDirs()
+	if err != nil {
+		return false
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return false
+	}
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return false
+	}
+	for i, dir := range dirs {
+		if real, errLink := filepath.EvalSymlinks(dir); errLink == nil {
+			dirs[i] = real
+		}
+	}
+	return underDirs(path, dirs)
+}
+
+func underDirs(path string, dirs []string) bool {
+	for _, dir := range dirs {
+		rel, err := filepath.Rel(dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Scan walks the configured directories, adds new audio files, updates the
+// ones whose modification time and content changed and marks songs whose
+// files are gone as missing. progress is called for every processed file.
+func (sc *Scanner) Scan(ctx context.Context, progress func(*ps.ScanProgress) error) (*ps.ScanProgress, error) {
+	if !sc.scanning.TryLock() {
+		return nil, ErrScanRunning
+	}
+	defer sc.scanning.Unlock()
+	dirs, err := sc.absDirs()
+	if err != nil {
+		return nil, err
+	}
+	sc.m.Lock()
+	defer sc.m.Unlock()
+	var known []*ps.SongInfo
+	if err := sc.DB.Where("path <> ''").Find(&known).Error; err != nil {
+		return nil, err
+	}
+	byPath := make(map[string]*ps.SongInfo, len(known))
+	missingByHash := make(map[string]*ps.SongInfo)
+	for _, song := range known {
+		byPath[song.Path] = song
+		if song.Missing && song.Hash != "" {
+			missingByHash[song.Hash] = song
+		}
+	}
+	total := &ps.ScanProgress{}
+	report := func(path, action string, err error) error {
+		if action != ActionRemoved {
+			total.Scanned++
+		}
+		switch action {
+		case ActionAdded:
+			total.Added++
+		case ActionUpdated:
+			total.Updated++
+		case ActionRemoved:
+			total.Removed++
+		case ActionFailed:
+			total.Failed++
+		}
+		if progress == nil {
+			return nil
+		}
+		event := &ps.ScanProgress{
+			Path:    path,
+			Action:  action,
+			Scanned: total.Scanned,
+			Added:   total.Added,
+			Updated: total.Updated,
+			Removed: total.Removed,
+			Failed:  total.Failed,
+		}
+		if err != nil {
+			event.Error = err.Error()
+		}
+		return progress(event)
+	}
+	seen := make(map[string]struct{})
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, errWalk error) error {
+			if errCtx := ctx.Err(); errCtx != nil {
+				return errCtx
+			}
+			if errWalk != nil {
+				return report(path, ActionFailed, errWalk)
+			}
+			if d.IsDir() || !d.Type().IsRegular() || !IsAudioFile(path) {
+				return nil
+			}
+			seen[path] = struct{}{}
+			action, errSync := sc.syncFile(path, byPath[path], func(hash string) *ps.SongInfo {
+				moved := missingByHash[hash]
+				delete(missingByHash, hash)
+				return moved
+			})
+			return report(path, action, errSync)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, song := range known {
+		if _, ok := seen[song.Path]; ok || song.Missing || !underDirs(song.Path, dirs) {
+			continue
+		}
+		action, err := ActionRemoved, sc.MarkMissing(song)
+		if err != nil {
+			action = ActionFailed
+		}
+		if err = report(song.Path, action, err); err != nil {
+			return nil, err
+		}
+	}
+	total.Done = true
+	return total, nil
+}
+
+// SyncFile brings the song stored for path in line with the file. A new file
+// whose content matches a missing song is treated as that song being moved.
+func (sc *Scanner) SyncFile(path string) (string, error) {
+	sc.m.Lock()
+	defer sc.m.Unlock()
+	var existing *ps.SongInfo
+	var found []*ps.SongInfo
+	if err := sc.DB.Where("path = ?", path).Limit(1).Find(&found).Error; err != nil {
+		return ActionFailed, err
+	}
+	if len(found) > 0 {
+		existing = found[0]
+	}
+	return sc.syncFile(path, existing, func(hash string) *ps.SongInfo {
+		var moved []*ps.SongInfo
+		if err := sc.DB.Where("missing = ? AND hash = ?", true, hash).Limit(1).Find(&moved).Error; err != nil || len(moved) == 0 {
+			return nil
+		}
+		return moved[0]
+	})
+}
+
+// RemovePath marks every present song stored at path, or below it when path
+// was a directory, as missing.
+func (sc *Scanner) RemovePath(path string) (int, error) {
+	sc.m.Lock()
+	defer sc.m.Unlock()
+	var songs []*ps.SongInfo
+	prefix := strings.TrimSuffix(path, string(filepath.Separator)) + string(filepath.Separator)
+	err := sc.DB.Where("(path = ? OR path LIKE ? ESCAPE '\\') AND (missing IS NULL OR missing = ?)",
+		path, strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix)+"%", false).Find(&songs).Error
+	if err != nil {
+		return 0, err
+	}
+	for _, song := range songs {
+		if err = sc.MarkMissing(song); err != nil {
+			return 0, err
+		}
+	}
+	return len(songs), nil
+}
+
+func (sc *Scanner) syncFile(path string, existing *ps.SongInfo, findMoved func(hash string) *ps.SongInfo) (string, error) {
+	if existing != nil {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return ActionFailed, err
+		}
+		if existing.Mtime == stat.ModTime().UnixNano() && !existing.Missing {
+			return ActionUnchanged, nil
+		}
+	}
+	song, err := SongFromFile(path)
+	if err != nil {
+		return ActionFailed, err
+	}
+	if song.Duration == 0 {
+		return ActionFailed, ErrNoDuration
+	}
+	if existing == nil {
+		existing = findMoved(song.Hash)
+	}
+	if existing == nil {
+		if err = sc.AddSong(song); err != nil {
+			return ActionFailed, err
+		}
+		return ActionAdded, nil
+	}
+	if existing.Hash == song.Hash && existing.Path == song.Path && !existing.Missing {
+		err = sc.DB.Model(&ps.SongInfo{}).Where("id = ?", existing.Id).Update("mtime", song.Mtime).Error
+		if err != nil {
+			return ActionFailed, err
+		}
+		existing.Mtime = song.Mtime
+		return ActionUnchanged, nil
+	}
+	song.Id = existing.Id
+	if err = sc.UpdateSong(song); err != nil {
+		return ActionFailed, err
+	}
+	return ActionUpdated, nil
+}
+
+func (sc *Scanner) AddSong(song *ps.SongInfo) error {
+	song.Id = uuid.New().String()
+	if err := sc.DB.Create(song).Error; err != nil {
+		return err
+	}
+	sc.P.AddSong(song)
+	sc.notify(ActionAdded, song)
+	return nil
+}
+
+func (sc *Scanner) notify(action string, song *ps.SongInfo) {
+	if sc.OnChange != nil {
+		sc.OnChange(action, song)
+	}
+}
+
+// UpdateSong stores the new file details of song. A song that was missing is
+// put back into the playlist.
+func (sc *Scanner) UpdateSong(song *ps.SongInfo) error {
+	err := sc.DB.Model(&ps.SongInfo{}).Where("id = ?", song.Id).Updates(map[string]interface{}{
+		"title":    song.Title,
+		"duration": song.Duration,
+		"artist":   song.Artist,
+		"album":    song.Album,
+		"path":     song.Path,
+		"mtime":    song.Mtime,
+		"hash":     song.Hash,
+		"missing":  false,
+	}).Error
+	if err != nil {
+		return err
+	}
+	if !sc.P.UpdateSong(song) {
+		sc.P.AddSong(song)
+	}
+	sc.notify(ActionUpdated, song)
+	return nil
+}
+
+func (sc *Scanner) MarkMissing(song *ps.SongInfo) error {
+	err := sc.DB.Model(&ps.SongInfo{}).Where("id = ?", song.Id).Update("missing", true).Error
+	if err != nil {
+		return err
+	}
+	song.Missing = true
+	sc.P.DeleteSong(song.Id)
+	sc.notify(ActionRemoved, song)
+	return nil
+}
@@ -0,0 +1,130 @@
+package library
+
+import (
+	"context"
+	"errors"
+	"github.com/fsnotify/fsnotify"
+	"golang.org/x/exp/slog"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const DefaultDebounce = 500 * time.Millisecond
+
+// Watcher keeps the library up to date after the initial scan by listening to
+// filesystem notifications in the scanner directories. Events are collected
+// until Debounce passes without new ones, so a file being copied is read once
+// it is complete and a rename (remove + create) is handled in one batch.
+type Watcher struct {
+	Scanner  *Scanner
+	Debounce time.Duration
+}
+
+func (w *Watcher) Run(ctx context.Context) error {
+	dirs, err := w.Scanner.absDirs()
+	if err != nil {
+		return err
+	}
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer fw.Close()
+	for _, dir := range dirs {
+		if err = addRecursive(fw, dir); err != nil {
+			return err
+		}
+	}
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultDebounce
+	}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	pending := make(map[string]struct{})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&fsnotify.Create != 0 {
+				if stat, errStat := os.Stat(event.Name); errStat == nil && stat.IsDir() {
+					if errAdd := addRecursive(fw, event.Name); errAdd != nil {
+						slog.Warn("failed to watch directory", "path", event.Name, "err", errAdd)
+					}
+				}
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+			pending[event.Name] = struct{}{}
+			timer.Reset(debounce)
+		case errWatch, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			slog.Warn("library watcher error", "err", errWatch)
+		case <-timer.C:
+			w.flush(pending)
+			pending = make(map[string]struct{})
+		}
+	}
+}
+
+func addRecursive(fw *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return fw.Add(path)
+		}
+		return nil
+	})
+}
+
+// flush applies a batch of changed paths. Removals go first so that files
+// which were moved show up as missing songs and the following sync of their
+// new location keeps the song id instead of creating a duplicate.
+func (w *Watcher) flush(pending map[string]struct{}) {
+	paths := make([]string, 0, len(pending))
+	for path := range pending {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var changed []string
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			if _, errRemove := w.Scanner.RemovePath(path); errRemove != nil {
+				slog.Warn("failed to remove song", "path", path, "err", errRemove)
+			}
+		case err != nil:
+			slog.Warn("failed to stat file", "path", path, "err", err)
+		case stat.IsDir():
+			_ = filepath.WalkDir(path, func(file string, d fs.DirEntry, errWalk error) error {
+				if errWalk == nil && d.Type().IsRegular() && IsAudioFile(file) {
+					changed = append(changed, file)
+				}
+				return nil
+			})
+		case stat.Mode().IsRegular() && IsAudioFile(path):
+			changed = append(changed, path)
+		}
+	}
+	for _, path := range changed {
+		if _, err := w.Scanner.SyncFile(path); err != nil {
+			slog.Warn("failed to sync file", "path", path, "err", err)
+		}
+	}
+}
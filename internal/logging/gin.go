@@ -0,0 +1,41 @@
+package logging
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/exp/slog"
+	"net/http"
+	"time"
+)
+
+// Middleware takes the request id from the X-Request-Id header or generates
+// one, echoes it in the response and puts a logger with it in the request
+// context. Requests are logged when their handler returns, so streams of
+// events are logged once they end.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" {
+			id = uuid.New().String()
+		}
+		c.Header(RequestIDHeader, id)
+		ctx := NewContext(c.Request.Context(), logger, id)
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		status := c.Writer.Status()
+		attrs := []any{"method", c.Request.Method, "path", c.Request.URL.Path, "status", status,
+			"duration", time.Since(start), "peer", c.ClientIP()}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "err", c.Errors.String())
+		}
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+		FromContext(ctx).Log(ctx, level, "http", attrs...)
+	}
+}
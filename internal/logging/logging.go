@@ -0,0 +1,81 @@
+// Package logging configures the structured logger of the server and carries
+// per-request loggers, tagged with a request id, in contexts.
+package logging
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/exp/slog"
+	"io"
+	"strings"
+)
+
+var ErrBadLevel = errors.New("logging error: level must be debug, info, warn or error")
+var ErrBadFormat = errors.New("logging error: format must be text or json")
+
+// New returns a logger writing to w at the given level in the text or json
+// format.
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	opts := slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "text", "":
+		return slog.New(opts.NewTextHandler(w)), nil
+	case "json":
+		return slog.New(opts.NewJSONHandler(w)), nil
+	}
+	return nil, ErrBadFormat
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, ErrBadLevel
+}
+
+type loggerKey struct{}
+
+type requestIDKey struct{}
+
+// NewContext returns a copy of ctx that carries a logger tagged with the
+// request id and, when ctx is traced, the trace id.
+func NewContext(ctx context.Context, logger *slog.Logger, requestID string) context.Context {
+	logger = logger.With("request_id", requestID)
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		logger = logger.With("trace_id", span.TraceID().String())
+	}
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// With returns a copy of ctx whose logger adds args to every record.
+func With(ctx context.Context, args ...any) context.Context {
+	return context.WithValue(ctx, loggerKey{}, FromContext(ctx).With(args...))
+}
+
+// FromContext returns the logger of the request in ctx, or the default logger
+// outside of requests.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// RequestID returns the id of the request in ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
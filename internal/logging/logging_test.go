@@ -0,0 +1,141 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		level, format string
+		wantErr       error
+		wantDebug     bool
+		wantJSON      bool
+	}{
+		{"debug", "json", nil, true, true},
+		{"INFO", "text", nil, false, false},
+		{"", "", nil, false, false},
+		{"warn", "json", nil, false, true},
+		{"verbose", "text", ErrBadLevel, false, false},
+		{"info", "xml", ErrBadFormat, false, false},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		logger, err := New(&buf, test.level, test.format)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s/%s -> \nWant: %v\nGot : %v", test.level, test.format, test.wantErr, err)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		if got := logger.Enabled(context.Background(), slog.LevelDebug); got != test.wantDebug {
+			t.Errorf("%s debug enabled -> \nWant: %v\nGot : %v", test.level, test.wantDebug, got)
+		}
+		logger.Error("message")
+		if got := json.Valid(buf.Bytes()); got != test.wantJSON {
+			t.Errorf("%s output %q -> \nWant JSON: %v\nGot : %v", test.format, buf.String(), test.wantJSON, got)
+		}
+	}
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var res []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("bad log line %q: %v", line, err)
+		}
+		res = append(res, record)
+	}
+	return res
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	var buf bytes.Buffer
+	logger, _ := New(&buf, "info", "json")
+	interceptor := UnaryInterceptor(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/playlist.PlaylistService/Play"}
+	var handlerID string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerID = RequestID(ctx)
+		FromContext(ctx).Info("inside")
+		return nil, status.Error(codes.NotFound, "missing")
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDHeader, "abc"))
+	if _, err := interceptor(ctx, nil, info, handler); status.Code(err) != codes.NotFound {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if handlerID != "abc" {
+		t.Errorf("request id -> \nWant: %v\nGot : %v", "abc", handlerID)
+	}
+	got := records(t, &buf)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 records, got %v", got)
+	}
+	if got[0]["msg"] != "inside" || got[0]["request_id"] != "abc" {
+		t.Errorf("handler record %v", got[0])
+	}
+	rpc := got[1]
+	if rpc["msg"] != "rpc" || rpc["level"] != "WARN" || rpc["method"] != info.FullMethod || rpc["code"] != "NotFound" || rpc["request_id"] != "abc" {
+		t.Errorf("rpc record %v", rpc)
+	}
+	if _, ok := rpc["duration"]; !ok {
+		t.Errorf("rpc record has no duration: %v", rpc)
+	}
+
+	buf.Reset()
+	if _, err := interceptor(context.Background(), nil, info, handler); err == nil {
+		t.Fatal("expected an error")
+	}
+	if handlerID == "" || handlerID == "abc" {
+		t.Errorf("expected a generated request id, got %q", handlerID)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	logger, _ := New(&buf, "info", "json")
+	r := gin.New()
+	r.Use(Middleware(logger))
+	r.GET("/v1/songs", func(c *gin.Context) {
+		c.String(http.StatusOK, RequestID(c.Request.Context()))
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/songs", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	r.ServeHTTP(w, req)
+	if w.Body.String() != "abc" || w.Header().Get(RequestIDHeader) != "abc" {
+		t.Errorf("request id -> body %q, header %q", w.Body.String(), w.Header().Get(RequestIDHeader))
+	}
+	got := records(t, &buf)
+	if len(got) != 1 || got[0]["msg"] != "http" || got[0]["path"] != "/v1/songs" || got[0]["status"] != float64(http.StatusOK) || got[0]["request_id"] != "abc" {
+		t.Errorf("http record %v", got)
+	}
+
+	buf.Reset()
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	if w.Header().Get(RequestIDHeader) == "" {
+		t.Errorf("expected a generated request id")
+	}
+	if got = records(t, &buf); got[0]["level"] != "WARN" {
+		t.Errorf("not found record %v", got[0])
+	}
+}
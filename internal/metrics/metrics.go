@@ -0,0 +1,96 @@
+// Package metrics exposes Prometheus metrics of the server: gRPC calls,
+// the player and database queries.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sgoldenf/playlist/internal/server"
+	"net/http"
+)
+
+// Path is where the HTTP server serves the metrics.
+const Path = "/metrics"
+
+const namespace = "playlist"
+
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	rpcDuration   *prometheus.HistogramVec
+	queryDuration *prometheus.HistogramVec
+}
+
+// New registers the metrics of service together with the Go runtime and
+// process collectors.
+func New(service *server.PlaylistService) *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "grpc",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of gRPC calls by method and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "db",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of database queries by operation and table.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"operation", "table"}),
+	}
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.rpcDuration,
+		m.queryDuration,
+		gauge("songs", "Number of songs in the playlist.", func() float64 {
+			return float64(service.P.Len())
+		}),
+		gauge("player_playing", "1 while the player is playing.", func() float64 {
+			if _, _, playing := service.P.Current(); playing {
+				return 1
+			}
+			return 0
+		}),
+		gauge("player_position_seconds", "Position in the current song.", func() float64 {
+			_, elapsed, _ := service.P.Current()
+			return float64(elapsed)
+		}),
+		gauge("player_streams", "Connected Player streams.", func() float64 {
+			return float64(service.PlayerStreams())
+		}),
+		gauge("sessions", "Open player sessions of users.", func() float64 {
+			return float64(service.ActiveSessions())
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tracks_completed_total",
+			Help:      "Songs played to the end.",
+		}, func() float64 {
+			completed, _ := service.P.TrackStats()
+			return float64(completed)
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tracks_skipped_total",
+			Help:      "Songs left for another one before their end.",
+		}, func() float64 {
+			_, skipped := service.P.TrackStats()
+			return float64(skipped)
+		}),
+	)
+	return m
+}
+
+func gauge(name, help string, value func() float64) prometheus.Collector {
+	return prometheus.NewGaugeFunc(prometheus.GaugeOpts{Namespace: namespace, Name: name, Help: help}, value)
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{})
+}
@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	dto "github.com/prometheus/client_model/go"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/server"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestMetrics() (*Metrics, *server.PlaylistService) {
+	service := &server.PlaylistService{P: playlist.NewPlaylist([]*ps.SongInfo{
+		{Id: "1", Title: "So What", Duration: 100},
+		{Id: "2", Title: "Blue In Green", Duration: 200},
+	})}
+	return New(service), service
+}
+
+// find returns the metric of the family name whose labels include labels.
+func find(t *testing.T, m *Metrics, name string, labels map[string]string) *dto.Metric {
+	t.Helper()
+	families, err := m.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.Metric {
+			for _, pair := range metric.Label {
+				if want, ok := labels[pair.GetName()]; ok && want != pair.GetValue() {
+					continue metrics
+				}
+			}
+			return metric
+		}
+	}
+	t.Fatalf("no metric %s%v", name, labels)
+	return nil
+}
+
+func TestMetrics_Player(t *testing.T) {
+	m, service := newTestMetrics()
+	if err := service.P.Seek(42); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		want float64
+	}{
+		{"playlist_songs", 2},
+		{"playlist_player_playing", 0},
+		{"playlist_player_position_seconds", 42},
+		{"playlist_player_streams", 0},
+	}
+	for _, test := range tests {
+		if got := find(t, m, test.name, nil).GetGauge().GetValue(); got != test.want {
+			t.Errorf("%s -> \nWant: %v\nGot : %v", test.name, test.want, got)
+		}
+	}
+	if err := service.P.PlaySong("2"); err != nil {
+		t.Fatal(err)
+	}
+	defer service.P.Pause()
+	if got := find(t, m, "playlist_tracks_skipped_total", nil).GetCounter().GetValue(); got != 1 {
+		t.Errorf("Out -> \nWant: 1 skipped\nGot : %v", got)
+	}
+	if got := find(t, m, "playlist_player_playing", nil).GetGauge().GetValue(); got != 1 {
+		t.Errorf("Out -> \nWant: playing\nGot : %v", got)
+	}
+}
+
+func TestMetrics_Interceptors(t *testing.T) {
+	m, _ := newTestMetrics()
+	unary := m.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/playlist.PlaylistService/GetSong"}
+	for _, err := range []error{nil, nil, status.Error(codes.NotFound, "song not found")} {
+		_, _ = unary(context.Background(), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, err
+		})
+	}
+	stream := m.StreamInterceptor()
+	_ = stream(nil, nil, &grpc.StreamServerInfo{FullMethod: "/playlist.PlaylistService/Player"}, func(interface{}, grpc.ServerStream) error {
+		return nil
+	})
+	tests := []struct {
+		method, code string
+		want         uint64
+	}{
+		{"/playlist.PlaylistService/GetSong", "OK", 2},
+		{"/playlist.PlaylistService/GetSong", "NotFound", 1},
+		{"/playlist.PlaylistService/Player", "OK", 1},
+	}
+	for _, test := range tests {
+		metric := find(t, m, "playlist_grpc_request_duration_seconds", map[string]string{"method": test.method, "code": test.code})
+		if got := metric.GetHistogram().GetSampleCount(); got != test.want {
+			t.Errorf("%s %s -> \nWant: %d\nGot : %d", test.method, test.code, test.want, got)
+		}
+	}
+}
+
+func TestMetrics_GORM(t *testing.T) {
+	m, _ := newTestMetrics()
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(m.GORM()); err != nil {
+		t.Fatal(err)
+	}
+	var songs []ps.SongInfo
+	db.Find(&songs)
+	db.Where("id = ?", "1").Delete(&ps.SongInfo{})
+	for _, operation := range []string{"query", "delete"} {
+		metric := find(t, m, "playlist_db_query_duration_seconds", map[string]string{"operation": operation, "table": "song_infos"})
+		if got := metric.GetHistogram().GetSampleCount(); got != 1 {
+			t.Errorf("%s -> \nWant: 1\nGot : %d", operation, got)
+		}
+	}
+}
+
+func TestMetrics_Handler(t *testing.T) {
+	m, _ := newTestMetrics()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", Path, nil))
+	if body := rec.Body.String(); rec.Code != 200 || !strings.Contains(body, "playlist_songs 2") || !strings.Contains(body, "go_goroutines") {
+		t.Errorf("unexpected response %d:\n%s", rec.Code, body)
+	}
+}
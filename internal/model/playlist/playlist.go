@@ -1,11 +1,26 @@
 package playlist
 
 import (
+	"context"
+	"errors"
 	ps "github.com/sgoldenf/playlist/api"
+	"golang.org/x/exp/slog"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// lockPollInterval is how often Responsive tries to take the lock.
+const lockPollInterval = 10 * time.Millisecond
+
+var (
+	ErrSongIsPlaying  = errors.New("delete error: song is currently playing")
+	ErrEmptyPlaylist  = errors.New("seek error: playlist is empty")
+	ErrSeekOutOfRange = errors.New("seek error: position is beyond the end of the song")
+	ErrSongNotFound   = errors.New("playlist error: song is not in the playlist")
+	ErrMoveOutOfRange = errors.New("move error: position is out of range")
+)
+
 type song struct {
 	Info        ps.SongInfo
 	ElapsedTime uint64
@@ -14,18 +29,28 @@ type song struct {
 }
 
 type Playlist struct {
-	len       int
-	pause     chan struct{}
+	len int
+	// stop is closed to end the play goroutine started by the last Play.
+	stop      chan struct{}
 	m         sync.Mutex
 	IsPlaying bool
 	Cur       *song
 	head      *song
 	tail      *song
+	// tick is the time of the last step of the play goroutine in
+	// nanoseconds since the epoch.
+	tick atomic.Int64
+	// completed and skipped count songs played to the end and songs left
+	// before it.
+	completed atomic.Uint64
+	skipped   atomic.Uint64
+	// picker chooses the song played after the current one ends, see
+	// SetPicker. It is read by the play goroutine while it holds the lock.
+	picker atomic.Pointer[func(has func(id string) bool) string]
 }
 
 func NewPlaylist(songs []*ps.SongInfo) *Playlist {
 	p := new(Playlist)
-	p.pause = make(chan struct{})
 	for _, s := range songs {
 		p.AddSong(s)
 	}
@@ -33,12 +58,23 @@ func NewPlaylist(songs []*ps.SongInfo) *Playlist {
 }
 
 func (p *Playlist) AddSong(info *ps.SongInfo) {
+	p.m.Lock()
+	p.addSong(info)
+	p.m.Unlock()
+}
+
+func (p *Playlist) AddSongs(infos []*ps.SongInfo) {
+	p.m.Lock()
+	for _, info := range infos {
+		p.addSong(info)
+	}
+	p.m.Unlock()
+}
+
+func (p *Playlist) addSong(info *ps.SongInfo) {
 	s := new(song)
-	s.Info.Id = info.Id
-	s.Info.Title = info.Title
-	s.Info.Duration = info.Duration
+	copyInfo(&s.Info, info)
 	s.ElapsedTime = 0
-	p.m.Lock()
 	if p.head == nil {
 		p.head = s
 		p.tail = s
@@ -52,58 +88,263 @@ func (p *Playlist) AddSong(info *ps.SongInfo) {
 		p.tail = s
 	}
 	p.len++
-	p.m.Unlock()
+}
+
+func copyInfo(dst, src *ps.SongInfo) {
+	dst.Id = src.Id
+	dst.Title = src.Title
+	dst.Duration = src.Duration
+	dst.Artist = src.Artist
+	dst.Album = src.Album
+	dst.Path = src.Path
+	dst.Mtime = src.Mtime
+	dst.Hash = src.Hash
+	dst.Missing = src.Missing
+}
+
+func (p *Playlist) UpdateSong(info *ps.SongInfo) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	for s := p.head; s != nil; s = s.next {
+		if s.Info.Id == info.Id {
+			copyInfo(&s.Info, info)
+			return true
+		}
+	}
+	return false
+}
+
+// Current returns a copy of the current song together with its elapsed time.
+func (p *Playlist) Current() (*ps.SongInfo, uint64, bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.Cur == nil {
+		return nil, 0, false
+	}
+	info := new(ps.SongInfo)
+	copyInfo(info, &p.Cur.Info)
+	return info, p.Cur.ElapsedTime, p.IsPlaying
+}
+
+// PlayingSong reports whether the song with the given id is being played.
+func (p *Playlist) PlayingSong(id string) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.IsPlaying && p.Cur != nil && p.Cur.Info.Id == id
+}
+
+func (p *Playlist) Len() int {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.len
+}
+
+// TrackStats returns how many songs were played to the end and how many were
+// left for another one before.
+func (p *Playlist) TrackStats() (completed, skipped uint64) {
+	return p.completed.Load(), p.skipped.Load()
+}
+
+// Songs returns copies of all songs in playback order.
+func (p *Playlist) Songs() []*ps.SongInfo {
+	p.m.Lock()
+	defer p.m.Unlock()
+	songs := make([]*ps.SongInfo, 0, p.len)
+	for s := p.head; s != nil; s = s.next {
+		info := new(ps.SongInfo)
+		copyInfo(info, &s.Info)
+		songs = append(songs, info)
+	}
+	return songs
+}
+
+// Move puts the song with the given id at the zero-based position. The current
+// song stays current and keeps playing.
+func (p *Playlist) Move(id string, position int) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if position < 0 || position >= p.len {
+		return ErrMoveOutOfRange
+	}
+	s := p.find(id)
+	if s == nil {
+		return ErrSongNotFound
+	}
+	p.unlink(s)
+	var at *song
+	if position > 0 {
+		at = p.head
+		for i := 1; i < position; i++ {
+			at = at.next
+		}
+	}
+	p.link(s, at)
+	return nil
+}
+
+func (p *Playlist) find(id string) *song {
+	s := p.head
+	for s != nil && s.Info.Id != id {
+		s = s.next
+	}
+	return s
 }
 
 func (p *Playlist) Play() {
+	p.m.Lock()
+	p.play()
+	p.m.Unlock()
+}
+
+func (p *Playlist) play() {
 	if p.len > 0 && !p.IsPlaying {
 		p.IsPlaying = true
 		if p.Cur == nil {
 			p.Cur = p.head
 		}
-		go p.playRoutine()
+		p.tick.Store(time.Now().UnixNano())
+		slog.Debug("playback started", "song", p.Cur.Info.Id, "elapsed", p.Cur.ElapsedTime)
+		p.stop = make(chan struct{})
+		go p.playRoutine(p.stop)
 	}
 }
 
-func (p *Playlist) playRoutine() {
+func (p *Playlist) playRoutine(stop chan struct{}) {
 	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
 	for {
 		select {
-		case <-p.pause:
+		case <-stop:
 			return
 		case <-ticker.C:
-			p.Cur.ElapsedTime++
-			if p.Cur.ElapsedTime == p.Cur.Info.Duration {
-				p.IsPlaying = false
-				if p.Cur == p.tail {
-					p.Cur.ElapsedTime = 0
-				} else {
-					go p.Next()
-				}
+			if !p.step(stop) {
 				return
 			}
 		}
 	}
 }
 
+// step counts one more second of the current song and reports whether it
+// goes on playing. A song whose duration was shortened below the elapsed time
+// ends at once.
+func (p *Playlist) step(stop chan struct{}) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	select {
+	case <-stop:
+		return false
+	default:
+	}
+	p.tick.Store(time.Now().UnixNano())
+	p.Cur.ElapsedTime++
+	if p.Cur.ElapsedTime < p.Cur.Info.Duration {
+		return true
+	}
+	p.completed.Add(1)
+	slog.Debug("song completed", "song", p.Cur.Info.Id)
+	p.IsPlaying = false
+	if p.Cur == p.tail && !p.hasPicker() {
+		p.Cur.ElapsedTime = 0
+	} else {
+		go p.advance()
+	}
+	return false
+}
+
+// SetPicker makes the playlist ask pick for the id of the song to play when
+// the current one ends. pick gets a function that tells whether a song is in
+// the playlist, so it only gives up a candidate that can be played. An empty
+// id, or one that left the playlist meanwhile, falls back to the next song.
+// nil removes the picker.
+func (p *Playlist) SetPicker(pick func(has func(id string) bool) string) {
+	if pick == nil {
+		p.picker.Store(nil)
+	} else {
+		p.picker.Store(&pick)
+	}
+}
+
+// has reports whether the song with the given id is in the playlist.
+func (p *Playlist) has(id string) bool {
+	p.m.Lock()
+	defer p.m.Unlock()
+	return p.find(id) != nil
+}
+
+func (p *Playlist) hasPicker() bool {
+	return p.picker.Load() != nil
+}
+
+// advance switches to the song after the one that ended: the picked one if
+// there is a picker, the next one otherwise. After the last song the playlist
+// stops at its beginning.
+func (p *Playlist) advance() {
+	if pick := p.picker.Load(); pick != nil {
+		if id := (*pick)(p.has); id != "" && p.PlaySong(id) == nil {
+			return
+		}
+	}
+	p.m.Lock()
+	if p.Cur == p.tail {
+		p.Cur.ElapsedTime = 0
+	}
+	p.m.Unlock()
+	p.Next()
+}
+
+// Responsive reports whether the lock of the playlist can be taken before ctx
+// is done. It only tries to take it, so a lock that is never released leaves
+// nothing waiting for it.
+func (p *Playlist) Responsive(ctx context.Context) bool {
+	ticker := time.NewTicker(lockPollInterval)
+	defer ticker.Stop()
+	for {
+		if p.m.TryLock() {
+			p.m.Unlock()
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stalled reports whether the playlist is playing but its play goroutine has
+// not advanced for longer than d.
+func (p *Playlist) Stalled(d time.Duration) bool {
+	p.m.Lock()
+	playing := p.IsPlaying
+	p.m.Unlock()
+	return playing && time.Since(time.Unix(0, p.tick.Load())) > d
+}
+
 func (p *Playlist) Pause() {
+	p.m.Lock()
+	p.pause()
+	p.m.Unlock()
+}
+
+func (p *Playlist) pause() {
 	if p.IsPlaying {
 		p.IsPlaying = false
-		p.pause <- struct{}{}
+		close(p.stop)
+		slog.Debug("playback paused", "song", p.Cur.Info.Id, "elapsed", p.Cur.ElapsedTime)
 	}
 }
 
 func (p *Playlist) Next() {
 	p.m.Lock()
 	if p.Cur != p.tail {
-		p.Pause()
+		p.pause()
 		if p.Cur != nil && p.Cur.next != nil {
-			p.Cur.ElapsedTime = 0
+			p.leave()
 			p.Cur = p.Cur.next
-			p.Play()
+			p.play()
 		} else if p.Cur == nil && p.len > 2 {
 			p.Cur = p.head.next
-			p.Play()
+			p.play()
 		}
 	}
 	p.m.Unlock()
@@ -112,40 +353,189 @@ func (p *Playlist) Next() {
 func (p *Playlist) Prev() {
 	p.m.Lock()
 	if p.Cur != nil && p.Cur != p.head {
-		p.Pause()
+		p.pause()
 		if p.Cur != nil && p.Cur.prev != nil {
-			p.Cur.ElapsedTime = 0
+			p.leave()
 			p.Cur = p.Cur.prev
-			p.Play()
+			p.play()
 		}
 	}
 	p.m.Unlock()
 }
 
+// PlaySong makes the song with the given id current and plays it from the
+// beginning.
+func (p *Playlist) PlaySong(id string) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	s := p.find(id)
+	if s == nil {
+		return ErrSongNotFound
+	}
+	p.pause()
+	if p.Cur != nil {
+		p.leave()
+	}
+	p.Cur = s
+	p.Cur.ElapsedTime = 0
+	p.play()
+	return nil
+}
+
+// leave rewinds the current song before another one becomes current and
+// counts it as skipped unless it was played to the end.
+func (p *Playlist) leave() {
+	if p.Cur.ElapsedTime < p.Cur.Info.Duration {
+		p.skipped.Add(1)
+		slog.Debug("song skipped", "song", p.Cur.Info.Id, "elapsed", p.Cur.ElapsedTime)
+	}
+	p.Cur.ElapsedTime = 0
+}
+
+// Restore makes the song with the given id current at elapsed seconds without
+// starting playback, so that a restarted server resumes where it stopped.
+func (p *Playlist) Restore(id string, elapsed uint64) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	s := p.find(id)
+	if s == nil {
+		return ErrSongNotFound
+	}
+	if elapsed >= s.Info.Duration {
+		return ErrSeekOutOfRange
+	}
+	p.pause()
+	if p.Cur != nil {
+		p.Cur.ElapsedTime = 0
+	}
+	p.Cur = s
+	p.Cur.ElapsedTime = elapsed
+	return nil
+}
+
+// Seek moves the current song, or the first one if nothing was played yet, to
+// position seconds.
+func (p *Playlist) Seek(position uint64) error {
+	p.m.Lock()
+	defer p.m.Unlock()
+	if p.Cur == nil {
+		p.Cur = p.head
+	}
+	if p.Cur == nil {
+		return ErrEmptyPlaylist
+	}
+	if position >= p.Cur.Info.Duration {
+		return ErrSeekOutOfRange
+	}
+	p.Cur.ElapsedTime = position
+	return nil
+}
+
 func (p *Playlist) DeleteSong(id string) {
 	p.m.Lock()
-	if p.IsPlaying && id == p.Cur.Info.Id {
-		p.m.Unlock()
-		return
+	if !(p.IsPlaying && id == p.Cur.Info.Id) {
+		p.deleteSong(id)
 	}
-	s := p.head
-	for s != nil && s.Info.Id != id {
-		s = s.next
+	p.m.Unlock()
+}
+
+// DeleteSongs removes the songs with the given ids, except the one being
+// played, whose id it returns. It returns "" when all of them were removed.
+// The Removal puts songs back where they were.
+func (p *Playlist) DeleteSongs(ids []string) (string, *Removal) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	want := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
 	}
-	if s != nil {
-		if s == p.head {
-			p.head = s.next
+	kept := ""
+	r := &Removal{p: p, after: make(map[string]string)}
+	for s := p.head; s != nil; s = s.next {
+		if !want[s.Info.Id] {
+			continue
 		}
-		if s == p.tail {
-			p.tail = s.prev
+		if p.IsPlaying && s == p.Cur {
+			kept = s.Info.Id
+			continue
 		}
+		r.songs = append(r.songs, s)
+		r.after[s.Info.Id] = ""
 		if s.prev != nil {
-			s.prev.next = s.next
+			r.after[s.Info.Id] = s.prev.Info.Id
 		}
-		if s.next != nil {
-			s.next.prev = s.prev
+	}
+	for _, s := range r.songs {
+		p.unlink(s)
+	}
+	return kept, r
+}
+
+// Removal remembers the songs taken out by DeleteSongs in playback order
+// together with the id of the song before each of them.
+type Removal struct {
+	p     *Playlist
+	songs []*song
+	after map[string]string
+}
+
+// Undo puts the removed songs that restore accepts back after the song that
+// was before them. When that song is gone too, the one before it is tried; a
+// song with nothing left before it goes to the head.
+func (r *Removal) Undo(restore func(id string) bool) {
+	p := r.p
+	p.m.Lock()
+	defer p.m.Unlock()
+	for _, s := range r.songs {
+		if !restore(s.Info.Id) || p.find(s.Info.Id) != nil {
+			continue
 		}
-		p.len--
+		var at *song
+		for id := r.after[s.Info.Id]; id != "" && at == nil; id = r.after[id] {
+			at = p.find(id)
+		}
+		p.link(s, at)
 	}
-	p.m.Unlock()
+}
+
+func (p *Playlist) deleteSong(id string) {
+	if s := p.find(id); s != nil {
+		p.unlink(s)
+	}
+}
+
+// link puts s after at, or at the head if at is nil.
+func (p *Playlist) link(s, at *song) {
+	if at == nil {
+		s.prev, s.next = nil, p.head
+	} else {
+		s.prev, s.next = at, at.next
+	}
+	if s.prev != nil {
+		s.prev.next = s
+	} else {
+		p.head = s
+	}
+	if s.next != nil {
+		s.next.prev = s
+	} else {
+		p.tail = s
+	}
+	p.len++
+}
+
+func (p *Playlist) unlink(s *song) {
+	if s == p.head {
+		p.head = s.next
+	}
+	if s == p.tail {
+		p.tail = s.prev
+	}
+	if s.prev != nil {
+		s.prev.next = s.next
+	}
+	if s.next != nil {
+		s.next.prev = s.prev
+	}
+	p.len--
 }
@@ -1,6 +1,8 @@
 package playlist
 
 import (
+	"context"
+	"errors"
 	ps "github.com/sgoldenf/playlist/api"
 	"math/rand"
 	"reflect"
@@ -390,3 +392,325 @@ func TestPlaylist_DeleteSong2(t *testing.T) {
 		t.Errorf("delete song error")
 	}
 }
+
+func TestPlaylist_AddSongs(t *testing.T) {
+	p := NewPlaylist(songs[:1])
+	p.AddSongs(songs[1:])
+	if p.len != 3 {
+		t.Errorf("len is %d, expected %d", p.len, 3)
+	}
+	if !reflect.DeepEqual(&p.head.next.Info, song2) || !reflect.DeepEqual(&p.tail.Info, song3) {
+		t.Errorf("WrongSongInfo\nSong2:\n%v\nexpected\n%v\nSong3:\n%v\nexpected\n%v",
+			&p.head.next.Info, song2, &p.tail.Info, song3)
+	}
+	if p.tail.prev != p.head.next {
+		t.Errorf("expected p.tail.prev == p.head.next")
+	}
+}
+
+func TestPlaylist_DeleteSongs(t *testing.T) {
+	p := NewPlaylist(songs)
+	p.Play()
+	if kept, _ := p.DeleteSongs([]string{"uuid3", "uuid1"}); kept != "uuid1" {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", "uuid1", kept)
+	}
+	p.Pause()
+	if p.len != 2 {
+		t.Errorf("len is %d, expected %d", p.len, 2)
+	}
+	if kept, _ := p.DeleteSongs([]string{"uuid1", "unknown"}); kept != "" {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", "", kept)
+	}
+	if p.len != 1 {
+		t.Errorf("len is %d, expected %d", p.len, 1)
+	}
+	if p.head != p.tail || !reflect.DeepEqual(&p.head.Info, song2) {
+		t.Errorf("delete songs error")
+	}
+}
+
+func TestPlaylist_RemovalUndo(t *testing.T) {
+	song4 := &ps.SongInfo{Id: "uuid4", Title: "song 4", Duration: 1}
+	song5 := &ps.SongInfo{Id: "uuid5", Title: "song 5", Duration: 1}
+	all := func(string) bool { return true }
+	tests := []struct {
+		name    string
+		ids     []string
+		restore func(id string) bool
+		want    []string
+	}{
+		{"all", []string{"uuid5", "uuid2", "uuid1", "uuid3"}, all, []string{"uuid1", "uuid2", "uuid3", "uuid4", "uuid5"}},
+		{"some", []string{"uuid1", "uuid2", "uuid4"}, func(id string) bool { return id == "uuid2" }, []string{"uuid2", "uuid3", "uuid5"}},
+		{"after a gone song", []string{"uuid3", "uuid4"}, func(id string) bool { return id == "uuid4" }, []string{"uuid1", "uuid2", "uuid4", "uuid5"}},
+	}
+	for _, tt := range tests {
+		p := NewPlaylist([]*ps.SongInfo{song1, song2, song3, song4, song5})
+		_, removal := p.DeleteSongs(tt.ids)
+		if p.Len() != 5-len(tt.ids) {
+			t.Errorf("%s: len is %d after delete", tt.name, p.Len())
+		}
+		p.AddSong(&ps.SongInfo{Id: "uuid6", Title: "song 6", Duration: 1})
+		removal.Undo(tt.restore)
+		p.DeleteSong("uuid6")
+		var got []string
+		for _, s := range p.Songs() {
+			got = append(got, s.Id)
+		}
+		if !reflect.DeepEqual(got, tt.want) || p.Len() != len(tt.want) || p.tail.Info.Id != tt.want[len(tt.want)-1] {
+			t.Errorf("%s: Out -> \nWant: %v\nGot : %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestPlaylist_UpdateSong(t *testing.T) {
+	p := NewPlaylist(songs)
+	updated := &ps.SongInfo{Id: "uuid2", Title: "artist2 - song2 (remastered)", Duration: 5, Path: "/music/song2.flac"}
+	if !p.UpdateSong(updated) {
+		t.Errorf("expected UpdateSong to find uuid2")
+	}
+	if !reflect.DeepEqual(&p.head.next.Info, updated) {
+		t.Errorf("WrongSongInfo\n%v\nexpected\n%v", &p.head.next.Info, updated)
+	}
+	if p.UpdateSong(&ps.SongInfo{Id: "invalid"}) {
+		t.Errorf("expected UpdateSong to return false for unknown id")
+	}
+}
+
+func TestPlaylist_UpdateSongPlaying(t *testing.T) {
+	p := NewPlaylist(songs)
+	if err := p.Seek(3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Play()
+	defer p.Pause()
+	p.UpdateSong(&ps.SongInfo{Id: song1.Id, Title: song1.Title, Duration: 1})
+	time.Sleep(1*time.Second + 200*time.Millisecond)
+	if info, elapsed, _ := p.Current(); info.Id != song2.Id || elapsed > 1 {
+		t.Errorf("Current() = %v, %d; expected %v after the shortened song ended", info, elapsed, song2)
+	}
+	if completed, _ := p.TrackStats(); completed != 1 {
+		t.Errorf("expected the shortened song to complete, got %d", completed)
+	}
+}
+
+func TestPlaylist_Current(t *testing.T) {
+	p := NewPlaylist(songs)
+	if info, _, _ := p.Current(); info != nil {
+		t.Errorf("expected no current song, got %v", info)
+	}
+	p.Play()
+	time.Sleep(1*time.Second + 100*time.Millisecond)
+	info, elapsed, playing := p.Current()
+	p.Pause()
+	if !reflect.DeepEqual(info, song1) || elapsed != 1 || !playing {
+		t.Errorf("Current() = %v, %d, %v; expected %v, 1, true", info, elapsed, playing, song1)
+	}
+	if info == &p.Cur.Info {
+		t.Errorf("expected a copy of the current song")
+	}
+}
+
+func TestPlaylist_Seek(t *testing.T) {
+	if err := NewPlaylist(nil).Seek(1); !errors.Is(err, ErrEmptyPlaylist) {
+		t.Errorf("expected ErrEmptyPlaylist, got %v", err)
+	}
+	p := NewPlaylist(songs)
+	if err := p.Seek(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info, elapsed, _ := p.Current(); info.Id != song1.Id || elapsed != 2 {
+		t.Errorf("Current() = %v, %d; expected %v, 2", info, elapsed, song1)
+	}
+	if err := p.Seek(song1.Duration); !errors.Is(err, ErrSeekOutOfRange) {
+		t.Errorf("expected ErrSeekOutOfRange, got %v", err)
+	}
+	p.Play()
+	time.Sleep(1*time.Second + 100*time.Millisecond)
+	_, elapsed, _ := p.Current()
+	p.Pause()
+	if elapsed != 3 {
+		t.Errorf("expected playback to continue from the seek position, elapsed %d", elapsed)
+	}
+}
+
+func TestPlaylist_Move(t *testing.T) {
+	ids := func(p *Playlist) []string {
+		var res []string
+		for _, s := range p.Songs() {
+			res = append(res, s.Id)
+		}
+		for s := p.tail; s != nil; s = s.prev {
+			if s.next == nil && s != p.tail || s.prev == nil && s != p.head {
+				t.Errorf("broken links around %s", s.Info.Id)
+			}
+		}
+		return res
+	}
+	tests := []struct {
+		id       string
+		position int
+		want     []string
+	}{
+		{song1.Id, 2, []string{song2.Id, song3.Id, song1.Id}},
+		{song1.Id, 0, []string{song1.Id, song2.Id, song3.Id}},
+		{song3.Id, 1, []string{song1.Id, song3.Id, song2.Id}},
+		{song2.Id, 1, []string{song1.Id, song2.Id, song3.Id}},
+	}
+	p := NewPlaylist(songs)
+	for _, test := range tests {
+		if err := p.Move(test.id, test.position); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := ids(p); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Out -> \nWant: %v\nGot : %v", test.want, got)
+		}
+	}
+	if err := p.Move(song1.Id, 3); !errors.Is(err, ErrMoveOutOfRange) {
+		t.Errorf("expected ErrMoveOutOfRange, got %v", err)
+	}
+	if err := p.Move("unknown", 0); !errors.Is(err, ErrSongNotFound) {
+		t.Errorf("expected ErrSongNotFound, got %v", err)
+	}
+	p.Cur = p.head
+	if err := p.Move(song1.Id, 2); err != nil || p.Cur.Info.Id != song1.Id || p.Cur != p.tail {
+		t.Errorf("current song should follow the move, got %v (%v)", p.Cur.Info.Id, err)
+	}
+}
+
+func TestPlaylist_PlaySong(t *testing.T) {
+	p := NewPlaylist(songs)
+	if err := p.PlaySong("unknown"); !errors.Is(err, ErrSongNotFound) {
+		t.Errorf("expected ErrSongNotFound, got %v", err)
+	}
+	if err := p.Seek(2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := p.PlaySong(song3.Id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, elapsed, playing := p.Current()
+	p.Pause()
+	if info.Id != song3.Id || elapsed != 0 || !playing || p.head.ElapsedTime != 0 {
+		t.Errorf("Current() = %v, %d, %v; expected %v playing from 0", info, elapsed, playing, song3)
+	}
+}
+
+func TestPlaylist_Restore(t *testing.T) {
+	p := NewPlaylist(songs)
+	if err := p.Restore("unknown", 0); !errors.Is(err, ErrSongNotFound) {
+		t.Errorf("expected ErrSongNotFound, got %v", err)
+	}
+	if err := p.Restore(song2.Id, song2.Duration); !errors.Is(err, ErrSeekOutOfRange) {
+		t.Errorf("expected ErrSeekOutOfRange, got %v", err)
+	}
+	p.Play()
+	if err := p.Restore(song2.Id, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	info, elapsed, playing := p.Current()
+	if info.Id != song2.Id || elapsed != 1 || playing || p.head.ElapsedTime != 0 {
+		t.Errorf("Current() = %v, %d, %v; expected paused %v at 1", info, elapsed, playing, song2)
+	}
+}
+
+func TestPlaylist_Stalled(t *testing.T) {
+	p := NewPlaylist(songs)
+	if p.Stalled(0) {
+		t.Errorf("a paused playlist is not stalled")
+	}
+	p.Play()
+	defer p.Pause()
+	if p.Stalled(time.Second) {
+		t.Errorf("a playlist that just started is not stalled")
+	}
+	p.tick.Store(time.Now().Add(-time.Minute).UnixNano())
+	if !p.Stalled(time.Second) {
+		t.Errorf("expected a playlist without ticks for a minute to be stalled")
+	}
+}
+
+func TestPlaylist_Responsive(t *testing.T) {
+	p := NewPlaylist(songs)
+	if !p.Responsive(context.Background()) {
+		t.Errorf("expected a free lock to be taken")
+	}
+	p.m.Lock()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if p.Responsive(ctx) {
+		t.Errorf("expected a held lock not to be taken")
+	}
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		p.m.Unlock()
+	}()
+	if !p.Responsive(context.Background()) {
+		t.Errorf("expected a released lock to be taken")
+	}
+}
+
+func TestPlaylist_TrackStats(t *testing.T) {
+	p := NewPlaylist(songs)
+	if p.Len() != len(songs) {
+		t.Errorf("Len() = %d, want %d", p.Len(), len(songs))
+	}
+	if err := p.PlaySong(song2.Id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	p.Next()
+	deadline := time.Now().Add(5 * time.Second)
+	for _, _, playing := p.Current(); playing && time.Now().Before(deadline); _, _, playing = p.Current() {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if completed, skipped := p.TrackStats(); completed != 1 || skipped != 1 {
+		t.Errorf("TrackStats() = %d, %d; want 1, 1", completed, skipped)
+	}
+}
+
+func TestPlaylist_SetPicker(t *testing.T) {
+	p := NewPlaylist([]*ps.SongInfo{song1, song2, song3, {Id: "uuid4", Title: "song 4", Duration: 1}})
+	picks := []string{"unknown", "uuid4"}
+	var m sync.Mutex
+	p.SetPicker(func(has func(id string) bool) string {
+		m.Lock()
+		defer m.Unlock()
+		for i, id := range picks {
+			if has(id) {
+				picks = append(picks[:i], picks[i+1:]...)
+				return id
+			}
+		}
+		return ""
+	})
+	if err := p.PlaySong(song2.Id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer p.Pause()
+	// song2 ends after 3 seconds, the picked uuid4 after another one; the
+	// unknown pick is never given up, so after uuid4 the playlist falls back
+	// to the next song, which is none.
+	want := []string{"uuid4"}
+	var got []string
+	deadline := time.Now().Add(8 * time.Second)
+	for time.Now().Before(deadline) {
+		info, _, playing := p.Current()
+		if info.Id != song2.Id && (len(got) == 0 || got[len(got)-1] != info.Id) {
+			got = append(got, info.Id)
+		}
+		if !playing && len(got) > 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+	}
+	if completed, skipped := p.TrackStats(); completed != 2 || skipped != 0 {
+		t.Errorf("TrackStats() = %d, %d; want 2, 0", completed, skipped)
+	}
+	m.Lock()
+	defer m.Unlock()
+	if want := []string{"unknown"}; !reflect.DeepEqual(picks, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, picks)
+	}
+}
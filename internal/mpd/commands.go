@@ -0,0 +1,645 @@
+package mpd
+
+import (
+	"context"
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errClose = errors.New("close")
+	errIdle  = errors.New("idle")
+)
+
+type command func(c *conn, args []string) error
+
+var commands map[string]command
+
+func init() {
+	commands = map[string]command{
+		"add":          (*conn).add,
+		"addid":        (*conn).addID,
+		"close":        func(*conn, []string) error { return errClose },
+		"commands":     (*conn).commands,
+		"currentsong":  (*conn).currentSong,
+		"delete":       (*conn).delete,
+		"deleteid":     (*conn).deleteID,
+		"idle":         (*conn).idleCommand,
+		"move":         (*conn).move,
+		"moveid":       (*conn).moveID,
+		"next":         (*conn).next,
+		"noidle":       func(*conn, []string) error { return nil },
+		"password":     (*conn).password,
+		"pause":        (*conn).pause,
+		"ping":         func(*conn, []string) error { return nil },
+		"play":         (*conn).play,
+		"playid":       (*conn).playID,
+		"playlistid":   (*conn).playlistID,
+		"playlistinfo": (*conn).playlistInfo,
+		"plchanges":    (*conn).plChanges,
+		"previous":     (*conn).previous,
+		"seekcur":      (*conn).seekCur,
+		"stats":        (*conn).stats,
+		"status":       (*conn).status,
+		"stop":         (*conn).stop,
+	}
+}
+
+// publicCommands work before the client sent a password.
+var publicCommands = map[string]bool{"close": true, "commands": true, "password": true, "ping": true}
+
+// commandMethods maps commands to the PlaylistService methods whose roles they
+// need.
+var commandMethods = map[string]string{
+	"add":          "CreateSongFromFile",
+	"addid":        "CreateSongFromFile",
+	"currentsong":  "GetPlayerState",
+	"delete":       "DeleteSong",
+	"deleteid":     "DeleteSong",
+	"idle":         "Player",
+	"move":         "MoveSong",
+	"moveid":       "MoveSong",
+	"next":         "Next",
+	"noidle":       "Player",
+	"pause":        "Pause",
+	"play":         "Play",
+	"playid":       "Play",
+	"playlistid":   "GetPlaylist",
+	"playlistinfo": "GetPlaylist",
+	"plchanges":    "GetPlaylist",
+	"previous":     "Prev",
+	"seekcur":      "Seek",
+	"stats":        "GetSongs",
+	"status":       "GetPlayerState",
+	"stop":         "Pause",
+}
+
+func (c *conn) run(name string, args []string) error {
+	cmd, ok := commands[name]
+	if !ok {
+		return ack(ackUnknown, "unknown command \"%s\"", name)
+	}
+	if !publicCommands[name] && c.s.Service.Auth.Enabled() {
+		if c.principal == nil || c.s.Service.Auth.Authorize(c.principal, commandMethods[name]) != nil {
+			return ack(ackPermission, "you don't have permission for \"%s\"", name)
+		}
+	}
+	return cmd(c, args)
+}
+
+// password authenticates the connection with an API key or a JWT.
+func (c *conn) password(args []string) error {
+	if err := wantArgs(args, 1, 1); err != nil {
+		return err
+	}
+	if !c.s.Service.Auth.Enabled() {
+		return nil
+	}
+	p, err := c.s.Service.Auth.Authenticate("Bearer "+args[0], "")
+	if err != nil {
+		return ack(ackPassword, "incorrect password")
+	}
+	c.principal = p
+	if c.s.Service.Sessions != nil {
+		c.subscribe()
+	}
+	return nil
+}
+
+// context returns the context of service calls, it carries the client that
+// sent a password.
+func (c *conn) context() context.Context {
+	if c.principal == nil {
+		return context.Background()
+	}
+	return auth.NewContext(context.Background(), c.principal)
+}
+
+// player returns the playlist of the connection, the session of the client
+// that sent a password when sessions are enabled.
+func (c *conn) player() *playlist.Playlist {
+	return c.s.Service.Playlist(c.context())
+}
+
+// subscribe follows the events of the player of the connection, which changes
+// when a password opens a session.
+func (c *conn) subscribe() {
+	if c.unsubscribe != nil {
+		c.unsubscribe()
+	}
+	c.events, c.unsubscribe = c.s.Service.Subscribe(c.context())
+}
+
+func wantArgs(args []string, min, max int) error {
+	if len(args) < min || len(args) > max {
+		return ack(ackArg, "wrong number of arguments")
+	}
+	return nil
+}
+
+func (c *conn) commands(args []string) error {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.pair("command", name)
+	}
+	return nil
+}
+
+func (c *conn) idleCommand(args []string) error {
+	c.startIdle(args)
+	return errIdle
+}
+
+// writeSong prints song the way MPD describes a playlist entry.
+func (c *conn) writeSong(song *ps.SongInfo, pos int) {
+	c.pair("file", c.uri(song))
+	if song.Artist != "" {
+		c.pair("Artist", song.Artist)
+	}
+	if song.Album != "" {
+		c.pair("Album", song.Album)
+	}
+	c.pair("Title", song.Title)
+	c.pair("Time", song.Duration)
+	c.pair("duration", seconds(song.Duration))
+	c.pair("Pos", pos)
+	c.pair("Id", c.s.songID(song.Id))
+}
+
+// uri is the path of the song relative to the music directory it is in, the
+// absolute path for files elsewhere and the song id for songs without a file.
+func (c *conn) uri(song *ps.SongInfo) string {
+	if song.Path == "" {
+		return song.Id
+	}
+	for _, dir := range c.s.Service.Scanner.Dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(abs, song.Path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return song.Path
+}
+
+func (c *conn) status(args []string) error {
+	songs := c.player().Songs()
+	current, elapsed, playing := c.player().Current()
+	c.pair("volume", -1)
+	c.pair("repeat", 0)
+	c.pair("random", 0)
+	c.pair("single", 0)
+	c.pair("consume", 0)
+	c.pair("playlist", c.s.playlistVersion(songs))
+	c.pair("playlistlength", len(songs))
+	pos := position(songs, current)
+	switch {
+	case current == nil || pos < 0:
+		c.pair("state", "stop")
+		return nil
+	case playing:
+		c.pair("state", "play")
+	default:
+		c.pair("state", "pause")
+	}
+	c.pair("song", pos)
+	c.pair("songid", c.s.songID(current.Id))
+	c.pair("time", strconv.FormatUint(elapsed, 10)+":"+strconv.FormatUint(current.Duration, 10))
+	c.pair("elapsed", seconds(elapsed))
+	c.pair("duration", seconds(current.Duration))
+	if pos+1 < len(songs) {
+		c.pair("nextsong", pos+1)
+		c.pair("nextsongid", c.s.songID(songs[pos+1].Id))
+	}
+	return nil
+}
+
+func position(songs []*ps.SongInfo, song *ps.SongInfo) int {
+	if song == nil {
+		return -1
+	}
+	for i, s := range songs {
+		if s.Id == song.Id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c *conn) currentSong(args []string) error {
+	songs := c.player().Songs()
+	current, _, _ := c.player().Current()
+	if pos := position(songs, current); pos >= 0 {
+		c.writeSong(songs[pos], pos)
+	}
+	return nil
+}
+
+func (c *conn) playlistInfo(args []string) error {
+	if err := wantArgs(args, 0, 1); err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	start, end := 0, len(songs)
+	if len(args) == 1 {
+		var err error
+		if start, end, err = parseRange(args[0], len(songs)); err != nil {
+			return err
+		}
+	}
+	for i := start; i < end; i++ {
+		c.writeSong(songs[i], i)
+	}
+	return nil
+}
+
+func (c *conn) playlistID(args []string) error {
+	if err := wantArgs(args, 0, 1); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return c.playlistInfo(nil)
+	}
+	songs := c.player().Songs()
+	pos, err := c.positionOfID(songs, args[0])
+	if err != nil {
+		return err
+	}
+	c.writeSong(songs[pos], pos)
+	return nil
+}
+
+// plChanges lists the whole playlist when it changed since version; the
+// service does not keep a history to tell which songs did.
+func (c *conn) plChanges(args []string) error {
+	if err := wantArgs(args, 1, 2); err != nil {
+		return err
+	}
+	version, err := parseInt(args[0])
+	if err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	if version == c.s.playlistVersion(songs) {
+		return nil
+	}
+	for i, song := range songs {
+		c.writeSong(song, i)
+	}
+	return nil
+}
+
+func (c *conn) stats(args []string) error {
+	res, err := c.s.Service.GetSongs(c.context(), &ps.ReadSongsRequest{})
+	var songs []*ps.SongInfo
+	if err == nil {
+		songs = res.Songs
+	}
+	artists, albums := make(map[string]bool), make(map[string]bool)
+	var total uint64
+	for _, song := range songs {
+		artists[song.Artist] = song.Artist != ""
+		albums[song.Album] = song.Album != ""
+		total += song.Duration
+	}
+	count := func(m map[string]bool) int {
+		n := 0
+		for _, ok := range m {
+			if ok {
+				n++
+			}
+		}
+		return n
+	}
+	c.pair("artists", count(artists))
+	c.pair("albums", count(albums))
+	c.pair("songs", len(songs))
+	c.pair("uptime", int(time.Since(c.s.started).Seconds()))
+	c.pair("db_playtime", total)
+	return nil
+}
+
+func (c *conn) play(args []string) error {
+	if err := wantArgs(args, 0, 1); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		_, err := c.s.Service.Play(c.context(), &ps.PlayRequest{})
+		return err
+	}
+	songs := c.player().Songs()
+	pos, err := parseInt(args[0])
+	if err != nil {
+		return err
+	}
+	if pos < 0 || pos >= len(songs) {
+		return ack(ackArg, "Bad song index")
+	}
+	return c.player().PlaySong(songs[pos].Id)
+}
+
+func (c *conn) playID(args []string) error {
+	if err := wantArgs(args, 0, 1); err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return c.play(nil)
+	}
+	songs := c.player().Songs()
+	pos, err := c.positionOfID(songs, args[0])
+	if err != nil {
+		return err
+	}
+	return c.player().PlaySong(songs[pos].Id)
+}
+
+// pause toggles playback without an argument, "pause 1" pauses and
+// "pause 0" resumes.
+func (c *conn) pause(args []string) error {
+	if err := wantArgs(args, 0, 1); err != nil {
+		return err
+	}
+	_, _, pause := c.player().Current()
+	if len(args) == 1 {
+		state, err := parseInt(args[0])
+		if err != nil || state < 0 || state > 1 {
+			return ack(ackArg, "Boolean (0/1) expected: %s", args[0])
+		}
+		pause = state == 1
+	}
+	var err error
+	if pause {
+		_, err = c.s.Service.Pause(c.context(), &ps.PauseRequest{})
+	} else {
+		_, err = c.s.Service.Play(c.context(), &ps.PlayRequest{})
+	}
+	return err
+}
+
+// stop pauses and rewinds the current song: the service has no stopped
+// state once something was played.
+func (c *conn) stop(args []string) error {
+	if _, err := c.s.Service.Pause(c.context(), &ps.PauseRequest{}); err != nil {
+		return err
+	}
+	if current, _, _ := c.player().Current(); current == nil {
+		return nil
+	}
+	_, err := c.s.Service.Seek(c.context(), &ps.SeekRequest{Position: 0})
+	return err
+}
+
+func (c *conn) next(args []string) error {
+	_, err := c.s.Service.Next(c.context(), &ps.NextSongRequest{})
+	return err
+}
+
+func (c *conn) previous(args []string) error {
+	_, err := c.s.Service.Prev(c.context(), &ps.PrevSongRequest{})
+	return err
+}
+
+// seekCur seeks to an absolute position or, with a leading sign, relative to
+// the current one. Fractions of a second are dropped.
+func (c *conn) seekCur(args []string) error {
+	if err := wantArgs(args, 1, 1); err != nil {
+		return err
+	}
+	arg := args[0]
+	offset, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return ack(ackArg, "Float expected: %s", arg)
+	}
+	target := offset
+	if strings.HasPrefix(arg, "+") || strings.HasPrefix(arg, "-") {
+		_, elapsed, _ := c.player().Current()
+		target = float64(elapsed) + offset
+	}
+	if target < 0 {
+		target = 0
+	}
+	_, err = c.s.Service.Seek(c.context(), &ps.SeekRequest{Position: uint64(target)})
+	if errors.Is(err, playlist.ErrEmptyPlaylist) {
+		return ack(ackNoExist, "Not playing")
+	}
+	return err
+}
+
+// add puts a file, or every audio file of a directory, from the music
+// directories into the song store and with that into the playlist.
+func (c *conn) add(args []string) error {
+	if err := wantArgs(args, 1, 1); err != nil {
+		return err
+	}
+	_, err := c.addPath(args[0])
+	return err
+}
+
+func (c *conn) addID(args []string) error {
+	if err := wantArgs(args, 1, 2); err != nil {
+		return err
+	}
+	if len(args) == 2 {
+		return ack(ackArg, "positions are not supported, use move")
+	}
+	paths, err := c.addPath(args[0])
+	if err != nil {
+		return err
+	}
+	if len(paths) != 1 {
+		return ack(ackArg, "addid needs a single file")
+	}
+	for _, song := range c.player().Songs() {
+		if song.Path == paths[0] {
+			c.pair("Id", c.s.songID(song.Id))
+			return nil
+		}
+	}
+	return ack(ackNoExist, "No such song")
+}
+
+func (c *conn) addPath(uri string) ([]string, error) {
+	path, err := c.resolve(uri)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, errWalk error) error {
+		if errWalk != nil {
+			return errWalk
+		}
+		if !d.IsDir() && library.IsAudioFile(p) {
+			paths = append(paths, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, ack(ackNoExist, "No audio files in %s", uri)
+	}
+	for _, p := range paths {
+		action, errSync := c.s.Service.Scanner.SyncFile(p)
+		if errSync != nil {
+			return nil, errSync
+		}
+		if action == library.ActionFailed {
+			return nil, ack(ackSystem, "Failed to read %s", p)
+		}
+	}
+	return paths, nil
+}
+
+// resolve finds uri below one of the music directories. Absolute paths are
+// accepted as well, as MPD does for local clients, as long as they are inside
+// the music directories too.
+func (c *conn) resolve(uri string) (string, error) {
+	scanner := c.s.Service.Scanner
+	if filepath.IsAbs(uri) {
+		path := filepath.Clean(uri)
+		if _, err := os.Stat(path); err != nil || !scanner.Contains(path) {
+			return "", ack(ackNoExist, "No such directory")
+		}
+		return path, nil
+	}
+	for _, dir := range scanner.Dirs {
+		path, err := filepath.Abs(filepath.Join(dir, filepath.FromSlash(uri)))
+		if err != nil {
+			continue
+		}
+		if _, err = os.Stat(path); err == nil && scanner.Contains(path) {
+			return path, nil
+		}
+	}
+	return "", ack(ackNoExist, "No such directory")
+}
+
+func (c *conn) delete(args []string) error {
+	if err := wantArgs(args, 1, 1); err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	start, end, err := parseRange(args[0], len(songs))
+	if err != nil {
+		return err
+	}
+	for _, song := range songs[start:end] {
+		if err = c.deleteSong(song.Id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *conn) deleteID(args []string) error {
+	if err := wantArgs(args, 1, 1); err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	pos, err := c.positionOfID(songs, args[0])
+	if err != nil {
+		return err
+	}
+	return c.deleteSong(songs[pos].Id)
+}
+
+func (c *conn) deleteSong(id string) error {
+	_, err := c.s.Service.DeleteSong(c.context(), &ps.DeleteSongRequest{Id: id})
+	if errors.Is(err, playlist.ErrSongIsPlaying) {
+		return ack(ackPermission, "%s", err)
+	}
+	return err
+}
+
+// move places the song at FROM, or the range START:END, at position TO. The
+// service moves one song at a time, so a range is moved song by song in the
+// order that keeps the songs already moved in place.
+func (c *conn) move(args []string) error {
+	if err := wantArgs(args, 2, 2); err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	start, end, err := parseRange(args[0], len(songs))
+	if err != nil {
+		return err
+	}
+	to, err := parseInt(args[1])
+	if err != nil {
+		return err
+	}
+	if to < 0 || to+end-start > len(songs) {
+		return ack(ackArg, "Bad song index")
+	}
+	ids := songs[start:end]
+	if to > start {
+		for i := len(ids) - 1; i >= 0; i-- {
+			if err = c.moveSong(ids[i].Id, to+i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i, song := range ids {
+		if err = c.moveSong(song.Id, to+i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *conn) moveID(args []string) error {
+	if err := wantArgs(args, 2, 2); err != nil {
+		return err
+	}
+	songs := c.player().Songs()
+	pos, err := c.positionOfID(songs, args[0])
+	if err != nil {
+		return err
+	}
+	to, err := parseInt(args[1])
+	if err != nil {
+		return err
+	}
+	if to < 0 || to >= len(songs) {
+		return ack(ackArg, "Bad song index")
+	}
+	return c.moveSong(songs[pos].Id, to)
+}
+
+func (c *conn) moveSong(id string, to int) error {
+	_, err := c.s.Service.MoveSong(c.context(), &ps.MoveSongRequest{Id: id, Position: uint64(to)})
+	return err
+}
+
+func (c *conn) positionOfID(songs []*ps.SongInfo, arg string) (int, error) {
+	n, err := parseInt(arg)
+	if err != nil {
+		return 0, err
+	}
+	if id, ok := c.s.serviceID(n); ok {
+		for i, song := range songs {
+			if song.Id == id {
+				return i, nil
+			}
+		}
+	}
+	return 0, ack(ackNoExist, "No such song")
+}
@@ -0,0 +1,387 @@
+package mpd
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	db "github.com/sgoldenf/playlist/db"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/server"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    []string
+		wantErr bool
+	}{
+		{"status", []string{"status"}, false},
+		{"  seekcur   +5 ", []string{"seekcur", "+5"}, false},
+		{`add "Bill Evans/Peace Piece.wav"`, []string{"add", "Bill Evans/Peace Piece.wav"}, false},
+		{`add "say \"hi\" \\ bye"`, []string{"add", `say "hi" \ bye`}, false},
+		{`add ""`, []string{"add", ""}, false},
+		{`add "open`, nil, true},
+	}
+	for _, test := range tests {
+		got, err := splitArgs(test.in)
+		if (err != nil) != test.wantErr || !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Out -> \nWant: %q %v\nGot : %q %v", test.want, test.wantErr, got, err)
+		}
+	}
+}
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end int
+		wantErr    bool
+	}{
+		{"0", 0, 1, false},
+		{"4", 4, 5, false},
+		{"1:3", 1, 3, false},
+		{"2:", 2, 5, false},
+		{"5", 0, 0, true},
+		{"3:2", 0, 0, true},
+		{"1:9", 0, 0, true},
+		{"x", 0, 0, true},
+	}
+	for _, test := range tests {
+		start, end, err := parseRange(test.in, 5)
+		if (err != nil) != test.wantErr || start != test.start || end != test.end {
+			t.Errorf("%s -> \nWant: %d %d %v\nGot : %d %d %v", test.in, test.start, test.end, test.wantErr, start, end, err)
+		}
+	}
+}
+
+func TestFormatAck(t *testing.T) {
+	got := formatAck(ack(ackArg, "Bad song index"), 2, "play")
+	if want := "ACK [2@2] {play} Bad song index\n"; got != want {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", want, got)
+	}
+}
+
+type testClient struct {
+	t *testing.T
+	c net.Conn
+	r *bufio.Reader
+}
+
+func dial(t *testing.T, addr string) *testClient {
+	c, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	client := &testClient{t: t, c: c, r: bufio.NewReader(c)}
+	if greeting := client.line(); greeting != "OK MPD "+Version {
+		t.Fatalf("unexpected greeting %q", greeting)
+	}
+	return client
+}
+
+func (c *testClient) line() string {
+	c.t.Helper()
+	_ = c.c.SetReadDeadline(time.Now().Add(3 * time.Second))
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		c.t.Fatalf("read error: %v", err)
+	}
+	return strings.TrimSuffix(line, "\n")
+}
+
+// command sends lines and returns the response up to OK, or the ACK line as
+// an error message.
+func (c *testClient) command(lines ...string) ([]string, string) {
+	c.t.Helper()
+	if _, err := c.c.Write([]byte(strings.Join(lines, "\n") + "\n")); err != nil {
+		c.t.Fatal(err)
+	}
+	return c.read()
+}
+
+func (c *testClient) read() ([]string, string) {
+	c.t.Helper()
+	var res []string
+	for {
+		line := c.line()
+		switch {
+		case line == "OK":
+			return res, ""
+		case strings.HasPrefix(line, "ACK "):
+			return res, line
+		}
+		res = append(res, line)
+	}
+}
+
+func (c *testClient) pairs(lines ...string) map[string]string {
+	c.t.Helper()
+	res, ack := c.command(lines...)
+	if ack != "" {
+		c.t.Fatalf("%v -> %s", lines, ack)
+	}
+	pairs := make(map[string]string)
+	for _, line := range res {
+		key, value, _ := strings.Cut(line, ": ")
+		if _, ok := pairs[key]; !ok {
+			pairs[key] = value
+		}
+	}
+	return pairs
+}
+
+func writeTestWAV(t *testing.T, path string, seconds int) {
+	wav := make([]byte, 44+8000*seconds)
+	copy(wav, "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:], uint32(len(wav)-8))
+	copy(wav[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(wav[16:], 16)
+	binary.LittleEndian.PutUint16(wav[20:], 1)
+	binary.LittleEndian.PutUint16(wav[22:], 1)
+	binary.LittleEndian.PutUint32(wav[24:], 8000)
+	binary.LittleEndian.PutUint32(wav[28:], 8000)
+	binary.LittleEndian.PutUint16(wav[32:], 1)
+	binary.LittleEndian.PutUint16(wav[34:], 8)
+	copy(wav[36:], "data")
+	binary.LittleEndian.PutUint32(wav[40:], uint32(8000*seconds))
+	if err := os.WriteFile(path, wav, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runTestServer(t *testing.T) (string, *server.PlaylistService) {
+	service, err := server.NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go New(service).Serve(l)
+	return l.Addr().String(), service
+}
+
+func TestServer(t *testing.T) {
+	addr, service := runTestServer(t)
+	defer service.P.Pause()
+	client := dial(t, addr)
+
+	songs := client.pairs("playlistinfo")
+	if songs["Pos"] != "0" || songs["Id"] == "" || songs["Title"] == "" {
+		t.Fatalf("unexpected playlistinfo %v", songs)
+	}
+	status := client.pairs("status")
+	length := status["playlistlength"]
+	if length == "0" || length == "" {
+		t.Fatalf("unexpected status %v", status)
+	}
+
+	watcher := dial(t, addr)
+	if _, err := watcher.c.Write([]byte("idle player\n")); err != nil {
+		t.Fatal(err)
+	}
+	if info := client.pairs("play 1"); len(info) != 0 {
+		t.Errorf("unexpected output of play: %v", info)
+	}
+	if changed, ack := watcher.read(); ack != "" || len(changed) != 1 || changed[0] != "changed: player" {
+		t.Errorf("idle -> %v %s", changed, ack)
+	}
+	current := client.pairs("currentsong")
+	if current["Pos"] != "1" {
+		t.Errorf("currentsong after play 1: %v", current)
+	}
+	client.pairs("seekcur 2")
+	if status = client.pairs("status"); status["state"] != "play" || status["song"] != "1" || (status["elapsed"] != "2.000" && status["elapsed"] != "3.000") {
+		t.Errorf("status after seekcur: %v", status)
+	}
+	if _, ack := client.command("seekcur 100000"); ack == "" {
+		t.Errorf("expected seek past the end to fail")
+	}
+	client.pairs("pause 1")
+	if status = client.pairs("status"); status["state"] != "pause" {
+		t.Errorf("status after pause: %v", status)
+	}
+
+	version := status["playlist"]
+	if _, err := watcher.c.Write([]byte("idle playlist\n")); err != nil {
+		t.Fatal(err)
+	}
+	client.pairs("move 1 0")
+	if changed, ack := watcher.read(); ack != "" || len(changed) != 1 || changed[0] != "changed: playlist" {
+		t.Errorf("idle -> %v %s", changed, ack)
+	}
+	if current = client.pairs("currentsong"); current["Pos"] != "0" {
+		t.Errorf("currentsong after move: %v", current)
+	}
+	if status = client.pairs("status"); status["playlist"] == version {
+		t.Errorf("playlist version did not change: %v", status)
+	}
+	client.pairs("move 0 1")
+
+	res, ack := client.command("command_list_ok_begin", "ping", "status", "command_list_end")
+	if ack != "" || res[0] != "list_OK" || res[len(res)-1] != "list_OK" {
+		t.Errorf("command list -> %v %s", res, ack)
+	}
+	if _, ack = client.command("command_list_begin", "ping", "play 100000", "ping", "command_list_end"); !strings.HasPrefix(ack, "ACK [2@1] {play}") {
+		t.Errorf("expected ACK for the second command, got %q", ack)
+	}
+	if _, ack = client.command("frobnicate"); !strings.HasPrefix(ack, "ACK [5@0] {frobnicate}") {
+		t.Errorf("unknown command -> %q", ack)
+	}
+
+	if _, err := watcher.c.Write([]byte("idle\nnoidle\n")); err != nil {
+		t.Fatal(err)
+	}
+	if changed, ack := watcher.read(); ack != "" {
+		t.Errorf("noidle -> %v %s", changed, ack)
+	}
+}
+
+func TestServer_AddDelete(t *testing.T) {
+	addr, service := runTestServer(t)
+	dir := t.TempDir()
+	service.Scanner.Dirs = []string{dir}
+	if err := os.Mkdir(filepath.Join(dir, "Bill Evans"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeTestWAV(t, filepath.Join(dir, "Bill Evans", "Peace Piece.wav"), 3)
+	client := dial(t, addr)
+
+	before := client.pairs("status")["playlistlength"]
+	added := client.pairs(`addid "Bill Evans/Peace Piece.wav"`)
+	if added["Id"] == "" {
+		t.Fatalf("addid returned %v", added)
+	}
+	song := client.pairs("playlistid " + added["Id"])
+	if song["file"] != "Bill Evans/Peace Piece.wav" || song["Title"] != "Peace Piece" || song["Time"] != "3" {
+		t.Errorf("added song: %v", song)
+	}
+	if status := client.pairs("status"); status["playlistlength"] == before {
+		t.Errorf("playlist did not grow: %v", status)
+	}
+	if _, ack := client.command(`add "Bill Evans/missing.wav"`); !strings.HasPrefix(ack, "ACK [50@0]") {
+		t.Errorf("add of a missing file -> %q", ack)
+	}
+	outside := t.TempDir()
+	writeTestWAV(t, filepath.Join(outside, "Waltz for Debby.wav"), 3)
+	if err := os.Symlink(outside, filepath.Join(dir, "link")); err != nil {
+		t.Fatal(err)
+	}
+	for _, uri := range []string{outside, "../" + filepath.Base(outside), "link/Waltz for Debby.wav", "/"} {
+		if _, ack := client.command(`add "` + filepath.ToSlash(uri) + `"`); !strings.HasPrefix(ack, "ACK [50@0]") {
+			t.Errorf("add of %s outside the music directories -> %q", uri, ack)
+		}
+	}
+	client.pairs("deleteid " + added["Id"])
+	if status := client.pairs("status"); status["playlistlength"] != before {
+		t.Errorf("playlist length after delete: %v, want %s", status, before)
+	}
+	if _, ack := client.command("deleteid " + added["Id"]); !strings.HasPrefix(ack, "ACK [50@0]") {
+		t.Errorf("second delete -> %q", ack)
+	}
+}
+
+func TestServer_Close(t *testing.T) {
+	service, err := server.NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := New(service)
+	served := make(chan error, 1)
+	go func() { served <- s.Serve(l) }()
+	client := dial(t, l.Addr().String())
+	client.pairs("ping")
+
+	if err = s.Close(); err != nil {
+		t.Fatalf("close error: %v", err)
+	}
+	if err = <-served; !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Serve returned %v, want %v", err, ErrServerClosed)
+	}
+	_ = client.c.SetReadDeadline(time.Now().Add(3 * time.Second))
+	if _, err = client.r.ReadString('\n'); err != io.EOF {
+		t.Errorf("expected the connection to be closed, got %v", err)
+	}
+	if err = s.Serve(l); !errors.Is(err, ErrServerClosed) {
+		t.Errorf("Serve after Close returned %v", err)
+	}
+}
+
+func TestServer_Password(t *testing.T) {
+	addr, service := runTestServer(t)
+	service.Auth = &auth.Authenticator{}
+	service.Auth.AddAPIKey("alice", "k-alice")
+	client := dial(t, addr)
+
+	client.pairs("ping")
+	if _, ack := client.command("status"); !strings.HasPrefix(ack, "ACK [4@0] {status}") {
+		t.Errorf("status without password -> %q", ack)
+	}
+	if _, ack := client.command("password k-eve"); !strings.HasPrefix(ack, "ACK [3@0] {password}") {
+		t.Errorf("wrong password -> %q", ack)
+	}
+	client.pairs("password k-alice")
+	if status := client.pairs("status"); status["state"] == "" {
+		t.Errorf("status after password: %v", status)
+	}
+
+	service.Auth.SetPolicy(&auth.Policy{Subjects: map[string]auth.Role{"alice": auth.RoleViewer}})
+	viewer := dial(t, addr)
+	viewer.pairs("password k-alice")
+	viewer.pairs("status")
+	if _, ack := viewer.command("next"); !strings.HasPrefix(ack, "ACK [4@0] {next}") {
+		t.Errorf("next as viewer -> %q", ack)
+	}
+	if _, ack := viewer.command("delete 0"); !strings.HasPrefix(ack, "ACK [4@0] {delete}") {
+		t.Errorf("delete as viewer -> %q", ack)
+	}
+}
+
+func TestServer_Sessions(t *testing.T) {
+	addr, service := runTestServer(t)
+	service.Auth = &auth.Authenticator{}
+	service.Auth.AddAPIKey("mpd-alice", "k-alice")
+	service.Auth.AddAPIKey("mpd-bob", "k-bob")
+	service.Sessions = server.NewSessions(time.Minute)
+	defer service.DB.Delete(&db.User{}, "id IN ?", []string{"mpd-alice", "mpd-bob"})
+	alice, bob := dial(t, addr), dial(t, addr)
+	alice.pairs("password k-alice")
+	bob.pairs("password k-bob")
+	defer service.Shutdown()
+
+	if _, err := bob.c.Write([]byte("idle player\n")); err != nil {
+		t.Fatal(err)
+	}
+	alice.pairs("play 1")
+	if status := alice.pairs("status"); status["state"] != "play" || status["song"] != "1" {
+		t.Errorf("status of alice: %v", status)
+	}
+	// Give the player watcher time to publish the track change.
+	time.Sleep(600 * time.Millisecond)
+	if _, err := bob.c.Write([]byte("noidle\n")); err != nil {
+		t.Fatal(err)
+	}
+	if changed, ack := bob.read(); ack != "" || len(changed) != 0 {
+		t.Errorf("the player of alice woke up bob: %v %s", changed, ack)
+	}
+	if status := bob.pairs("status"); status["state"] == "play" {
+		t.Errorf("status of bob: %v", status)
+	}
+	if got := service.ActiveSessions(); got != 2 {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", 2, got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package mpd serves the playlist over the Music Player Daemon protocol, so
+// that existing MPD clients such as mpc or ncmpcpp can control the player.
+package mpd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version is announced in the greeting. Clients use it to decide which
+// commands they may send.
+const Version = "0.23.0"
+
+// Error codes of ACK responses, see the MPD protocol documentation.
+const (
+	ackNotList    = 1
+	ackArg        = 2
+	ackPassword   = 3
+	ackPermission = 4
+	ackUnknown    = 5
+	ackNoExist    = 50
+	ackSystem     = 52
+)
+
+var errUnbalancedQuotes = errors.New("unbalanced quotes")
+
+// ackError is sent to the client as "ACK [code@index] {command} message".
+type ackError struct {
+	code    int
+	command string
+	message string
+}
+
+func (e *ackError) Error() string {
+	return e.message
+}
+
+func ack(code int, format string, args ...interface{}) error {
+	return &ackError{code: code, message: fmt.Sprintf(format, args...)}
+}
+
+// formatAck renders err as the ACK line of the command at index in a
+// command list.
+func formatAck(err error, index int, command string) string {
+	code, message := ackSystem, err.Error()
+	var ackErr *ackError
+	if errors.As(err, &ackErr) {
+		code = ackErr.code
+	}
+	return fmt.Sprintf("ACK [%d@%d] {%s} %s\n", code, index, command, message)
+}
+
+// splitArgs splits a command line into words. Arguments may be quoted with
+// double quotes, inside which a backslash escapes the next character.
+func splitArgs(line string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == ' ' || line[i] == '\t':
+			i++
+		case line[i] == '"':
+			var b strings.Builder
+			i++
+			for ; i < len(line) && line[i] != '"'; i++ {
+				if line[i] == '\\' && i+1 < len(line) {
+					i++
+				}
+				b.WriteByte(line[i])
+			}
+			if i == len(line) {
+				return nil, errUnbalancedQuotes
+			}
+			i++
+			args = append(args, b.String())
+		default:
+			start := i
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				i++
+			}
+			args = append(args, line[start:i])
+		}
+	}
+	return args, nil
+}
+
+func parseInt(arg string) (int, error) {
+	n, err := strconv.Atoi(arg)
+	if err != nil {
+		return 0, ack(ackArg, "Integer expected: %s", arg)
+	}
+	return n, nil
+}
+
+// parseRange parses "POS" or "START:END" (END exclusive, may be omitted) into
+// a half-open range clamped to length.
+func parseRange(arg string, length int) (int, int, error) {
+	startText, endText, isRange := strings.Cut(arg, ":")
+	start, err := parseInt(startText)
+	if err != nil {
+		return 0, 0, err
+	}
+	end := start + 1
+	if isRange {
+		end = length
+		if endText != "" {
+			if end, err = parseInt(endText); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	if start < 0 || end > length || start >= end {
+		return 0, 0, ack(ackArg, "Bad song index")
+	}
+	return start, end, nil
+}
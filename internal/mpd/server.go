@@ -0,0 +1,354 @@
+package mpd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/server"
+	"hash/fnv"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPort is the port MPD clients connect to unless told otherwise.
+const DefaultPort = 6600
+
+// ErrServerClosed is returned by Serve after Close.
+var ErrServerClosed = errors.New("mpd error: server closed")
+
+// maxLine bounds a single command line, MPD itself allows a few kilobytes.
+const maxLine = 64 * 1024
+
+// Server translates MPD commands into calls of the PlaylistService. MPD
+// identifies songs by small integers, so Server hands them out for the song
+// ids of the service and keeps the mapping for its lifetime.
+type Server struct {
+	Service *server.PlaylistService
+
+	m        sync.Mutex
+	ids      map[string]int
+	songs    map[int]string
+	version  int
+	checksum uint64
+	started  time.Time
+
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+func New(service *server.PlaylistService) *Server {
+	return &Server{
+		Service: service,
+		ids:     make(map[string]int),
+		songs:   make(map[int]string),
+		version: 1,
+		started: time.Now(),
+
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+func (s *Server) ListenAndServe(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(l)
+}
+
+func (s *Server) Serve(l net.Listener) error {
+	s.m.Lock()
+	if s.closed {
+		s.m.Unlock()
+		l.Close()
+		return ErrServerClosed
+	}
+	s.listeners[l] = struct{}{}
+	s.m.Unlock()
+	defer func() {
+		s.m.Lock()
+		delete(s.listeners, l)
+		s.m.Unlock()
+	}()
+	defer l.Close()
+	for {
+		nc, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return ErrServerClosed
+			}
+			return err
+		}
+		if !s.addConn(nc) {
+			nc.Close()
+			return ErrServerClosed
+		}
+		go s.serveConn(nc)
+	}
+}
+
+// Close stops the listeners and drops all client connections. MPD has no way
+// to tell clients about it, they see the connection closing.
+func (s *Server) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.closed = true
+	var err error
+	for l := range s.listeners {
+		if errClose := l.Close(); errClose != nil && err == nil {
+			err = errClose
+		}
+	}
+	for nc := range s.conns {
+		nc.Close()
+	}
+	return err
+}
+
+func (s *Server) isClosed() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.closed
+}
+
+// addConn registers nc for Close, it reports false when the server is closed
+// already.
+func (s *Server) addConn(nc net.Conn) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[nc] = struct{}{}
+	return true
+}
+
+func (s *Server) removeConn(nc net.Conn) {
+	s.m.Lock()
+	delete(s.conns, nc)
+	s.m.Unlock()
+}
+
+// songID returns the MPD id of the song with the given service id.
+func (s *Server) songID(id string) int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	n, ok := s.ids[id]
+	if !ok {
+		n = len(s.ids) + 1
+		s.ids[id] = n
+		s.songs[n] = id
+	}
+	return n
+}
+
+// serviceID is the reverse of songID.
+func (s *Server) serviceID(n int) (string, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	id, ok := s.songs[n]
+	return id, ok
+}
+
+// playlistVersion returns the MPD playlist version for songs. The playlist
+// is changed from many places, so instead of counting changes the version is
+// bumped whenever the contents differ from what was seen last time.
+func (s *Server) playlistVersion(songs []*ps.SongInfo) int {
+	h := fnv.New64a()
+	for _, song := range songs {
+		fmt.Fprintf(h, "%s\x00%s\x00%s\x00%s\x00%d\x00", song.Id, song.Title, song.Artist, song.Album, song.Duration)
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if sum := h.Sum64(); sum != s.checksum {
+		s.checksum = sum
+		s.version++
+	}
+	return s.version
+}
+
+type conn struct {
+	s *Server
+	w *bufio.Writer
+	// principal is the client authenticated by the password command.
+	principal *auth.Principal
+	// events follows the player of the connection, see subscribe.
+	events      <-chan *ps.PlayerInfo
+	unsubscribe func()
+	// changed collects the subsystems that changed since the last idle.
+	changed map[string]bool
+	// idle is the set of subsystems the client waits for, nil when it is
+	// not idle.
+	idle map[string]bool
+}
+
+func (s *Server) serveConn(nc net.Conn) {
+	defer s.removeConn(nc)
+	defer nc.Close()
+	c := &conn{s: s, w: bufio.NewWriter(nc), changed: make(map[string]bool)}
+	c.subscribe()
+	defer func() { c.unsubscribe() }()
+	lines := make(chan string)
+	done := make(chan struct{})
+	defer close(done)
+	go readLines(nc, lines, done)
+
+	c.w.WriteString("OK MPD " + Version + "\n")
+	var list []string
+	inList, listOK := false, false
+	for {
+		if err := c.w.Flush(); err != nil {
+			return
+		}
+		select {
+		case info := <-c.events:
+			c.notice(info)
+			continue
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			switch {
+			case c.idle != nil:
+				// The only command allowed while idle is noidle.
+				if strings.TrimSpace(line) != "noidle" {
+					return
+				}
+				c.finishIdle()
+			case inList && line == "command_list_end":
+				inList = false
+				if !c.runList(list, listOK) {
+					return
+				}
+			case inList:
+				list = append(list, line)
+			case line == "command_list_begin", line == "command_list_ok_begin":
+				inList, listOK, list = true, line == "command_list_ok_begin", nil
+			default:
+				if !c.runList([]string{line}, false) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func readLines(r io.Reader, lines chan<- string, done <-chan struct{}) {
+	defer close(lines)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 4096), maxLine)
+	for scanner.Scan() {
+		select {
+		case lines <- strings.TrimSuffix(scanner.Text(), "\r"):
+		case <-done:
+			return
+		}
+	}
+}
+
+// runList executes commands and writes OK, or an ACK for the first failing
+// one. It returns false when the connection has to be closed.
+func (c *conn) runList(commands []string, listOK bool) bool {
+	for i, line := range commands {
+		args, err := splitArgs(line)
+		if err == nil && len(args) == 0 {
+			err = ack(ackUnknown, "No command given")
+		}
+		name := ""
+		if len(args) > 0 {
+			name = args[0]
+		}
+		if err == nil && name == "idle" && (listOK || len(commands) > 1) {
+			err = ack(ackNotList, "idle is not allowed in a command list")
+		}
+		if err == nil {
+			err = c.run(name, args[1:])
+		}
+		if errors.Is(err, errClose) {
+			return false
+		}
+		if errors.Is(err, errIdle) {
+			// idle answers later, when something changes.
+			return true
+		}
+		if err != nil {
+			c.w.WriteString(formatAck(err, i, name))
+			return true
+		}
+		if listOK {
+			c.w.WriteString("list_OK\n")
+		}
+	}
+	c.w.WriteString("OK\n")
+	return true
+}
+
+// notice records which subsystems an event touched and answers a pending
+// idle that waits for one of them.
+func (c *conn) notice(info *ps.PlayerInfo) {
+	for _, subsystem := range subsystems(info.Event) {
+		c.changed[subsystem] = true
+	}
+	if c.idle != nil {
+		for subsystem := range c.changed {
+			if len(c.idle) == 0 || c.idle[subsystem] {
+				c.finishIdle()
+				return
+			}
+		}
+	}
+}
+
+func subsystems(event string) []string {
+	switch event {
+	case server.EventTrackChanged, server.EventPlaying, server.EventPaused, server.EventSeeked:
+		return []string{"player"}
+	case server.EventSongAdded, server.EventSongUpdated, server.EventSongRemoved:
+		return []string{"database", "playlist"}
+	case server.EventSongMoved:
+		return []string{"playlist"}
+	}
+	return nil
+}
+
+// startIdle answers at once when one of the requested subsystems already
+// changed, otherwise the answer is written by notice or noidle.
+func (c *conn) startIdle(names []string) {
+	c.idle = make(map[string]bool, len(names))
+	for _, name := range names {
+		c.idle[name] = true
+	}
+	for subsystem := range c.changed {
+		if len(c.idle) == 0 || c.idle[subsystem] {
+			c.finishIdle()
+			return
+		}
+	}
+}
+
+func (c *conn) finishIdle() {
+	for subsystem := range c.changed {
+		if len(c.idle) == 0 || c.idle[subsystem] {
+			c.w.WriteString("changed: " + subsystem + "\n")
+			delete(c.changed, subsystem)
+		}
+	}
+	c.idle = nil
+	c.w.WriteString("OK\n")
+}
+
+func (c *conn) pair(key string, value interface{}) {
+	c.w.WriteString(key + ": " + fmt.Sprint(value) + "\n")
+}
+
+func seconds(n uint64) string {
+	return strconv.FormatUint(n, 10) + ".000"
+}
@@ -0,0 +1,232 @@
+// Package party keeps the queue of songs that listeners suggest for the
+// shared player and vote on.
+package party
+
+import (
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	ErrDisabled    = errors.New("party error: party mode is off")
+	ErrQueued      = errors.New("party error: song is already in the queue")
+	ErrNotQueued   = errors.New("party error: song is not in the queue")
+	ErrBadVote     = errors.New("party error: vote must be -1, 0 or 1")
+	ErrRateLimited = errors.New("party error: too many requests, try again later")
+)
+
+// Limit allows Count actions of a user per Window. A zero Count means no
+// limit.
+type Limit struct {
+	Count  int
+	Window time.Duration
+}
+
+// Entry is a suggested song as a user sees it.
+type Entry struct {
+	Song        *ps.SongInfo
+	SuggestedBy string
+	// Score is the sum of the votes, the suggestion counts as an upvote.
+	Score int
+	// Vote is the vote of the user the entry was returned for.
+	Vote int
+}
+
+type entry struct {
+	song        *ps.SongInfo
+	suggestedBy string
+	score       int
+	seq         uint64
+	votes       map[string]int
+}
+
+// Queue orders suggestions by score, older ones first among equal scores.
+// A suggestion whose score drops below zero leaves the queue.
+type Queue struct {
+	// OnChange is called with the entries after every change, outside the
+	// lock of the queue.
+	OnChange func(entries []Entry)
+	m        sync.Mutex
+	// notify keeps OnChange calls in the order of the changes.
+	notify  sync.Mutex
+	entries []*entry
+	seq     uint64
+	suggest limiter
+	vote    limiter
+	now     func() time.Time
+}
+
+// NewQueue returns a queue that lets every user suggest and vote within the
+// given limits.
+func NewQueue(suggest, vote Limit) *Queue {
+	return &Queue{suggest: newLimiter(suggest), vote: newLimiter(vote), now: time.Now}
+}
+
+// Suggest puts song at the end of the entries with score 1, the upvote of
+// user.
+func (q *Queue) Suggest(user string, song *ps.SongInfo) error {
+	q.m.Lock()
+	if q.find(song.Id) != nil {
+		q.m.Unlock()
+		return ErrQueued
+	}
+	now := q.now()
+	if !q.suggest.allow(user, now) {
+		q.m.Unlock()
+		return ErrRateLimited
+	}
+	q.seq++
+	q.entries = append(q.entries, &entry{
+		song:        song,
+		suggestedBy: user,
+		score:       1,
+		seq:         q.seq,
+		votes:       map[string]int{user: 1},
+	})
+	q.sort()
+	q.m.Unlock()
+	q.changed()
+	return nil
+}
+
+// Vote sets the vote of user for the song with the given id: 1 for up, -1 for
+// down and 0 to take the vote back.
+func (q *Queue) Vote(user, id string, vote int) error {
+	if vote < -1 || vote > 1 {
+		return ErrBadVote
+	}
+	q.m.Lock()
+	e := q.find(id)
+	if e == nil {
+		q.m.Unlock()
+		return ErrNotQueued
+	}
+	if e.votes[user] == vote {
+		q.m.Unlock()
+		return nil
+	}
+	if !q.vote.allow(user, q.now()) {
+		q.m.Unlock()
+		return ErrRateLimited
+	}
+	e.score += vote - e.votes[user]
+	if vote == 0 {
+		delete(e.votes, user)
+	} else {
+		e.votes[user] = vote
+	}
+	if e.score < 0 {
+		q.remove(id)
+	}
+	q.sort()
+	q.m.Unlock()
+	q.changed()
+	return nil
+}
+
+// Pop removes the top entry whose song playable accepts and returns the id
+// of its song, or "" when there is none. Entries playable rejects stay in the
+// queue. A nil playable accepts every song.
+func (q *Queue) Pop(playable func(id string) bool) string {
+	q.m.Lock()
+	for i, e := range q.entries {
+		if playable == nil || playable(e.song.Id) {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			q.m.Unlock()
+			q.changed()
+			return e.song.Id
+		}
+	}
+	q.m.Unlock()
+	return ""
+}
+
+// Remove takes the song with the given id out of the queue, e.g. when it was
+// deleted from the library.
+func (q *Queue) Remove(id string) {
+	q.m.Lock()
+	removed := q.remove(id)
+	q.m.Unlock()
+	if removed {
+		q.changed()
+	}
+}
+
+// Entries returns the queue in playing order with the votes of user.
+func (q *Queue) Entries(user string) []Entry {
+	q.m.Lock()
+	defer q.m.Unlock()
+	entries := make([]Entry, len(q.entries))
+	for i, e := range q.entries {
+		entries[i] = Entry{Song: e.song, SuggestedBy: e.suggestedBy, Score: e.score, Vote: e.votes[user]}
+	}
+	return entries
+}
+
+func (q *Queue) changed() {
+	if q.OnChange != nil {
+		q.notify.Lock()
+		defer q.notify.Unlock()
+		q.OnChange(q.Entries(""))
+	}
+}
+
+func (q *Queue) find(id string) *entry {
+	for _, e := range q.entries {
+		if e.song.Id == id {
+			return e
+		}
+	}
+	return nil
+}
+
+func (q *Queue) remove(id string) bool {
+	for i, e := range q.entries {
+		if e.song.Id == id {
+			q.entries = append(q.entries[:i], q.entries[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Queue) sort() {
+	sort.SliceStable(q.entries, func(i, j int) bool {
+		if q.entries[i].score != q.entries[j].score {
+			return q.entries[i].score > q.entries[j].score
+		}
+		return q.entries[i].seq < q.entries[j].seq
+	})
+}
+
+// limiter keeps the times of the recent actions of every user.
+type limiter struct {
+	limit Limit
+	times map[string][]time.Time
+}
+
+func newLimiter(limit Limit) limiter {
+	return limiter{limit: limit, times: make(map[string][]time.Time)}
+}
+
+// allow records an action of user at now unless the user used up the limit.
+func (l *limiter) allow(user string, now time.Time) bool {
+	if l.limit.Count <= 0 {
+		return true
+	}
+	recent := l.times[user][:0]
+	for _, t := range l.times[user] {
+		if now.Sub(t) < l.limit.Window {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= l.limit.Count {
+		l.times[user] = recent
+		return false
+	}
+	l.times[user] = append(recent, now)
+	return true
+}
@@ -0,0 +1,130 @@
+package party
+
+import (
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var (
+	song1 = &ps.SongInfo{Id: "uuid1", Title: "song1", Duration: 4}
+	song2 = &ps.SongInfo{Id: "uuid2", Title: "song2", Duration: 3}
+	song3 = &ps.SongInfo{Id: "uuid3", Title: "song3", Duration: 2}
+)
+
+func ids(entries []Entry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.Song.Id
+	}
+	return result
+}
+
+func TestQueue_Vote(t *testing.T) {
+	q := NewQueue(Limit{}, Limit{})
+	var changes [][]string
+	q.OnChange = func(entries []Entry) {
+		changes = append(changes, ids(entries))
+	}
+	for _, song := range []*ps.SongInfo{song1, song2, song3} {
+		if err := q.Suggest("alice", song); err != nil {
+			t.Fatalf("suggest error: %v", err)
+		}
+	}
+	if err := q.Suggest("bob", song2); !errors.Is(err, ErrQueued) {
+		t.Errorf("expected ErrQueued, got %v", err)
+	}
+	tests := []struct {
+		user string
+		id   string
+		vote int
+		err  error
+		want []string
+	}{
+		{"bob", song3.Id, 1, nil, []string{"uuid3", "uuid1", "uuid2"}},
+		{"bob", song3.Id, 1, nil, []string{"uuid3", "uuid1", "uuid2"}},
+		{"carol", song2.Id, 1, nil, []string{"uuid2", "uuid3", "uuid1"}},
+		{"bob", song3.Id, 0, nil, []string{"uuid2", "uuid1", "uuid3"}},
+		{"bob", song1.Id, -1, nil, []string{"uuid2", "uuid3", "uuid1"}},
+		{"carol", song1.Id, -1, nil, []string{"uuid2", "uuid3"}},
+		{"carol", song1.Id, 1, ErrNotQueued, []string{"uuid2", "uuid3"}},
+		{"carol", song2.Id, 2, ErrBadVote, []string{"uuid2", "uuid3"}},
+	}
+	for _, test := range tests {
+		if err := q.Vote(test.user, test.id, test.vote); !errors.Is(err, test.err) {
+			t.Errorf("Vote(%s, %s, %d) -> \nWant: %v\nGot : %v", test.user, test.id, test.vote, test.err, err)
+		}
+		if got := ids(q.Entries(test.user)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Out -> \nWant: %v\nGot : %v", test.want, got)
+		}
+	}
+	entries := q.Entries("carol")
+	if entries[0].Score != 2 || entries[0].Vote != 1 || entries[0].SuggestedBy != "alice" || entries[1].Vote != 0 {
+		t.Errorf("unexpected entries %+v", entries)
+	}
+	// Three suggestions and five effective votes, the repeated vote and the
+	// failed ones change nothing.
+	if len(changes) != 8 {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", 8, len(changes))
+	}
+}
+
+func TestQueue_Pop(t *testing.T) {
+	q := NewQueue(Limit{}, Limit{})
+	if id := q.Pop(nil); id != "" {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", "", id)
+	}
+	_ = q.Suggest("alice", song1)
+	_ = q.Suggest("alice", song2)
+	_ = q.Vote("bob", song2.Id, 1)
+	q.Remove(song1.Id)
+	_ = q.Suggest("alice", song3)
+	_ = q.Suggest("alice", song1)
+	notUUID2 := func(id string) bool { return id != song2.Id }
+	var got []string
+	for id := q.Pop(notUUID2); id != ""; id = q.Pop(notUUID2) {
+		got = append(got, id)
+	}
+	if want := []string{"uuid3", "uuid1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+	}
+	if got = ids(q.Entries("")); !reflect.DeepEqual(got, []string{"uuid2"}) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", []string{"uuid2"}, got)
+	}
+	if id := q.Pop(nil); id != song2.Id {
+		t.Errorf("Out -> \nWant: %q\nGot : %q", song2.Id, id)
+	}
+}
+
+func TestQueue_RateLimit(t *testing.T) {
+	now := time.Unix(1000, 0)
+	q := NewQueue(Limit{Count: 2, Window: time.Minute}, Limit{Count: 1, Window: time.Minute})
+	q.now = func() time.Time { return now }
+	if err := q.Suggest("alice", song1); err != nil {
+		t.Fatalf("suggest error: %v", err)
+	}
+	if err := q.Suggest("alice", song2); err != nil {
+		t.Fatalf("suggest error: %v", err)
+	}
+	if err := q.Suggest("alice", song3); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	if err := q.Suggest("bob", song3); err != nil {
+		t.Errorf("the limit of alice applied to bob: %v", err)
+	}
+	if err := q.Vote("alice", song3.Id, 1); err != nil {
+		t.Fatalf("vote error: %v", err)
+	}
+	if err := q.Vote("alice", song3.Id, 0); !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if err := q.Suggest("alice", &ps.SongInfo{Id: "uuid4"}); err != nil {
+		t.Errorf("the limit did not expire: %v", err)
+	}
+	if err := q.Vote("alice", song3.Id, 0); err != nil {
+		t.Errorf("the limit did not expire: %v", err)
+	}
+}
@@ -0,0 +1,240 @@
+// Package radio implements an Icecast/SHOUTcast compatible live stream of
+// whatever the server-side player is playing. Audio bytes of the current MP3
+// are sent at the pace of the track, so every listener hears the same part of
+// the song; tracks in other formats are skipped silently.
+package radio
+
+import (
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/audio"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMetaInt = 16000
+	DefaultLead    = 2 * time.Second
+	DefaultTick    = 250 * time.Millisecond
+	maxDrift       = 2500 * time.Millisecond
+)
+
+type Source interface {
+	Current() (*ps.SongInfo, uint64, bool)
+}
+
+type Radio struct {
+	Source  Source
+	Name    string
+	MetaInt int
+	Lead    time.Duration
+	Tick    time.Duration
+	// Contains reports whether a file may be streamed; songs it rejects are
+	// skipped like tracks in other formats.
+	Contains func(path string) bool
+}
+
+func StreamTitle(song *ps.SongInfo) string {
+	if song.GetArtist() != "" {
+		return song.GetArtist() + " - " + song.GetTitle()
+	}
+	return song.GetTitle()
+}
+
+type track struct {
+	id       string
+	file     *os.File
+	layout   audio.MP3Layout
+	duration time.Duration
+	started  time.Time
+	pos      int64
+	paused   bool
+}
+
+func (t *track) close() {
+	if t != nil && t.file != nil {
+		t.file.Close()
+	}
+}
+
+func openTrack(song *ps.SongInfo, elapsed time.Duration, now time.Time, contains func(path string) bool) (*track, error) {
+	if song.GetPath() == "" || strings.ToLower(filepath.Ext(song.Path)) != ".mp3" || song.Duration == 0 {
+		return nil, fmt.Errorf("radio: %s is not an mp3 file", song.Id)
+	}
+	if contains == nil || !contains(song.Path) {
+		return nil, fmt.Errorf("radio: %s is outside the music directories", song.Id)
+	}
+	f, err := os.Open(song.Path)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	layout, err := audio.ReadMP3Layout(f, stat.Size())
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	t := &track{
+		id:       song.Id,
+		file:     f,
+		layout:   layout,
+		duration: time.Duration(song.Duration) * time.Second,
+		started:  now.Add(-elapsed),
+	}
+	t.pos = layout.Offset(f, elapsed, t.duration)
+	return t, nil
+}
+
+// target is the byte offset the listener should have received by now.
+func (t *track) target(now time.Time, lead time.Duration) int64 {
+	position := now.Sub(t.started) + lead
+	if position >= t.duration {
+		return t.layout.End
+	}
+	return t.layout.Start + int64(float64(t.layout.End-t.layout.Start)*float64(position)/float64(t.duration))
+}
+
+func (r *Radio) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	metaInt := 0
+	if req.Header.Get("Icy-MetaData") == "1" {
+		metaInt = r.MetaInt
+		if metaInt <= 0 {
+			metaInt = DefaultMetaInt
+		}
+	}
+	lead, tick := r.Lead, r.Tick
+	if lead <= 0 {
+		lead = DefaultLead
+	}
+	if tick <= 0 {
+		tick = DefaultTick
+	}
+	h := w.Header()
+	h.Set("Content-Type", "audio/mpeg")
+	h.Set("Cache-Control", "no-cache, no-store")
+	h.Set("icy-name", r.Name)
+	h.Set("icy-pub", "0")
+	if metaInt > 0 {
+		h.Set("icy-metaint", fmt.Sprint(metaInt))
+	}
+	w.WriteHeader(http.StatusOK)
+	if req.Method == http.MethodHead {
+		return
+	}
+	out := &icyWriter{w: w, metaInt: metaInt}
+	flusher, _ := w.(http.Flusher)
+	var cur *track
+	defer func() { cur.close() }()
+	skipped := ""
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		song, seconds, playing := r.Source.Current()
+		elapsed := time.Duration(seconds) * time.Second
+		switch {
+		case song == nil || song.Id == skipped:
+		case cur == nil || cur.id != song.Id:
+			cur.close()
+			next, err := openTrack(song, elapsed, now, r.Contains)
+			cur = next
+			if err != nil {
+				skipped = song.Id
+				break
+			}
+			skipped = ""
+			out.setTitle(StreamTitle(song))
+		case !playing:
+			cur.paused = true
+		case cur.paused:
+			cur.paused = false
+			cur.started = now.Add(-elapsed)
+		default:
+			if drift := now.Sub(cur.started) - elapsed; drift > maxDrift || drift < -maxDrift {
+				cur.started = now.Add(-elapsed)
+				cur.pos = cur.layout.Offset(cur.file, elapsed, cur.duration)
+			}
+		}
+		if cur != nil && playing && !cur.paused {
+			if target := cur.target(now, lead); target > cur.pos {
+				n, err := io.Copy(out, io.NewSectionReader(cur.file, cur.pos, target-cur.pos))
+				cur.pos += n
+				if err != nil {
+					return
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			}
+		}
+		select {
+		case <-req.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// icyWriter interleaves audio with ICY metadata blocks every metaInt bytes.
+type icyWriter struct {
+	w       io.Writer
+	metaInt int
+	count   int
+	title   string
+	sent    string
+}
+
+func (iw *icyWriter) setTitle(title string) {
+	iw.title = title
+}
+
+func (iw *icyWriter) Write(b []byte) (int, error) {
+	if iw.metaInt <= 0 {
+		return iw.w.Write(b)
+	}
+	written := 0
+	for len(b) > 0 {
+		n := iw.metaInt - iw.count
+		if n > len(b) {
+			n = len(b)
+		}
+		m, err := iw.w.Write(b[:n])
+		written += m
+		iw.count += m
+		if err != nil {
+			return written, err
+		}
+		b = b[n:]
+		if iw.count == iw.metaInt {
+			if _, err = iw.w.Write(iw.metadata()); err != nil {
+				return written, err
+			}
+			iw.count = 0
+		}
+	}
+	return written, nil
+}
+
+func (iw *icyWriter) metadata() []byte {
+	if iw.title == iw.sent {
+		return []byte{0}
+	}
+	iw.sent = iw.title
+	text := "StreamTitle='" + strings.ReplaceAll(iw.title, "'", "’") + "';"
+	if len(text) > 255*16 {
+		text = text[:255*16]
+	}
+	blocks := (len(text) + 15) / 16
+	meta := make([]byte, 1+blocks*16)
+	meta[0] = byte(blocks)
+	copy(meta[1:], text)
+	return meta
+}
@@ -0,0 +1,170 @@
+package radio
+
+import (
+	"bufio"
+	"bytes"
+	ps "github.com/sgoldenf/playlist/api"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	m       sync.Mutex
+	song    *ps.SongInfo
+	elapsed uint64
+	playing bool
+}
+
+func (s *fakeSource) Current() (*ps.SongInfo, uint64, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.song, s.elapsed, s.playing
+}
+
+func (s *fakeSource) set(song *ps.SongInfo, elapsed uint64, playing bool) {
+	s.m.Lock()
+	s.song, s.elapsed, s.playing = song, elapsed, playing
+	s.m.Unlock()
+}
+
+// writeMP3 writes a 128 kbit/s CBR file of 417 byte frames whose first byte
+// after the header is the frame number, so the test can tell frames apart.
+func writeMP3(t *testing.T, path string, frames int) {
+	var b bytes.Buffer
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, 417)
+		copy(frame, []byte{0xff, 0xfb, 0x90, 0x00, byte(i)})
+		b.Write(frame)
+	}
+	if err := os.WriteFile(path, b.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readICY(t *testing.T, r *bufio.Reader, metaInt int) ([]byte, string) {
+	audio := make([]byte, metaInt)
+	if _, err := io.ReadFull(r, audio); err != nil {
+		t.Fatalf("read audio: %v", err)
+	}
+	n, err := r.ReadByte()
+	if err != nil {
+		t.Fatalf("read metadata length: %v", err)
+	}
+	meta := make([]byte, int(n)*16)
+	if _, err = io.ReadFull(r, meta); err != nil {
+		t.Fatalf("read metadata: %v", err)
+	}
+	return audio, string(bytes.TrimRight(meta, "\x00"))
+}
+
+func inDir(dir string) func(path string) bool {
+	return func(path string) bool {
+		return filepath.Dir(path) == dir
+	}
+}
+
+func TestRadio_ServeHTTP(t *testing.T) {
+	dir := t.TempDir()
+	first := &ps.SongInfo{Id: "1", Title: "So What", Artist: "Miles Davis", Duration: 10, Path: filepath.Join(dir, "1.mp3")}
+	second := &ps.SongInfo{Id: "2", Title: "Blue In Green", Duration: 10, Path: filepath.Join(dir, "2.mp3")}
+	// 10 seconds of 128 kbit/s audio.
+	writeMP3(t, first.Path, 384)
+	writeMP3(t, second.Path, 384)
+
+	source := &fakeSource{}
+	source.set(first, 5, true)
+	const metaInt = 417
+	server := httptest.NewServer(&Radio{Source: source, Name: "test", MetaInt: metaInt, Lead: time.Second, Tick: 20 * time.Millisecond, Contains: inDir(dir)})
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set("Icy-MetaData", "1")
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	if res.Header.Get("Content-Type") != "audio/mpeg" || res.Header.Get("icy-metaint") != strconv.Itoa(metaInt) ||
+		res.Header.Get("icy-name") != "test" {
+		t.Errorf("unexpected headers: %v", res.Header)
+	}
+	r := bufio.NewReader(res.Body)
+
+	audio, meta := readICY(t, r, metaInt)
+	if audio[0] != 0xff || audio[4] != 192 {
+		t.Errorf("expected stream to start at frame 192 (5s), got frame %d", audio[4])
+	}
+	if meta != "StreamTitle='Miles Davis - So What';" {
+		t.Errorf("unexpected metadata %q", meta)
+	}
+	if _, meta = readICY(t, r, metaInt); meta != "" {
+		t.Errorf("expected empty metadata for unchanged title, got %q", meta)
+	}
+
+	source.set(second, 0, true)
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) {
+		audio, meta = readICY(t, r, metaInt)
+		if meta != "" {
+			break
+		}
+	}
+	if meta != "StreamTitle='Blue In Green';" {
+		t.Errorf("expected title of the second song, got %q", meta)
+	}
+	next, _ := readICY(t, r, metaInt)
+	if !bytes.Contains(append(audio, next...), []byte{0xff, 0xfb, 0x90, 0x00, 0x00}) {
+		t.Errorf("expected the second song from its beginning")
+	}
+}
+
+func TestRadio_Pacing(t *testing.T) {
+	dir := t.TempDir()
+	song := &ps.SongInfo{Id: "1", Title: "So What", Duration: 10, Path: filepath.Join(dir, "1.mp3")}
+	writeMP3(t, song.Path, 384)
+	source := &fakeSource{}
+	source.set(song, 0, true)
+	server := httptest.NewServer(&Radio{Source: source, Lead: 500 * time.Millisecond, Tick: 10 * time.Millisecond, Contains: inDir(dir)})
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	received := make(chan int, 1)
+	go func() {
+		n := 0
+		buf := make([]byte, 4096)
+		timeout := time.After(time.Second)
+		for {
+			select {
+			case <-timeout:
+				received <- n
+				return
+			default:
+			}
+			m, errRead := res.Body.Read(buf)
+			n += m
+			if errRead != nil {
+				received <- n
+				return
+			}
+		}
+	}()
+	n := <-received
+	// Roughly 1.5 seconds (lead + elapsed) of a 16000 bytes/s stream.
+	if n < 16000 || n > 32000 {
+		t.Errorf("received %d bytes in one second, expected about 24000", n)
+	}
+	if res.Header.Get("icy-metaint") != "" {
+		t.Errorf("expected no metadata without Icy-MetaData request header")
+	}
+}
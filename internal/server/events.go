@@ -0,0 +1,150 @@
+package server
+
+import (
+	"context"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"sync"
+	"time"
+)
+
+const (
+	EventSongAdded    = "song_added"
+	EventSongUpdated  = "song_updated"
+	EventSongRemoved  = "song_removed"
+	EventTrackChanged = "track_changed"
+	EventPlaying      = "playing"
+	EventPaused       = "paused"
+	EventSeeked       = "seeked"
+	EventSongMoved    = "song_moved"
+	EventPartyChanged = "party_changed"
+	EventShutdown     = "shutdown"
+)
+
+const (
+	subscriberBuffer   = 64
+	replayBuffer       = 256
+	playerPollInterval = 250 * time.Millisecond
+)
+
+// broker fans events out to Player streams. Every event gets an increasing
+// EventId and the last replayBuffer events are kept, so a client that
+// reconnects with the id of the last event it saw can catch up.
+type broker struct {
+	m      sync.Mutex
+	subs   map[chan *ps.PlayerInfo]struct{}
+	lastID uint64
+	recent []*ps.PlayerInfo
+	// done is closed after the final event, Player streams return then.
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func newBroker() *broker {
+	return &broker{subs: make(map[chan *ps.PlayerInfo]struct{}), done: make(chan struct{})}
+}
+
+// subscribe returns a channel of new events together with the buffered
+// events published after lastID; lastID 0 means no replay.
+func (b *broker) subscribe(lastID uint64) (chan *ps.PlayerInfo, []*ps.PlayerInfo) {
+	ch := make(chan *ps.PlayerInfo, subscriberBuffer)
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.subs[ch] = struct{}{}
+	var replay []*ps.PlayerInfo
+	if lastID > 0 {
+		for _, info := range b.recent {
+			if info.EventId > lastID {
+				replay = append(replay, info)
+			}
+		}
+	}
+	return ch, replay
+}
+
+func (b *broker) lastEventID() uint64 {
+	b.m.Lock()
+	defer b.m.Unlock()
+	return b.lastID
+}
+
+func (b *broker) unsubscribe(ch chan *ps.PlayerInfo) {
+	b.m.Lock()
+	delete(b.subs, ch)
+	b.m.Unlock()
+}
+
+// publish never blocks: a subscriber that does not keep up loses the event.
+func (b *broker) publish(info *ps.PlayerInfo) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.lastID++
+	info.EventId = b.lastID
+	if len(b.recent) == replayBuffer {
+		b.recent = append(b.recent[:0], b.recent[1:]...)
+	}
+	b.recent = append(b.recent, info)
+	for ch := range b.subs {
+		select {
+		case ch <- info:
+		default:
+		}
+	}
+}
+
+// close publishes info as the last event and ends the Player streams. Only
+// the first call has an effect.
+func (b *broker) close(info *ps.PlayerInfo) {
+	b.closeOnce.Do(func() {
+		b.publish(info)
+		close(b.done)
+	})
+}
+
+// Subscribe delivers the events of the Player stream of the caller in ctx to
+// listeners in the same process, such as the MPD server. The returned function
+// unsubscribes.
+func (s *PlaylistService) Subscribe(ctx context.Context) (<-chan *ps.PlayerInfo, func()) {
+	ss := s.session(ctx)
+	ss.streams.Add(1)
+	ch, _ := ss.events.subscribe(0)
+	return ch, func() {
+		ss.events.unsubscribe(ch)
+		ss.touch()
+		ss.streams.Add(-1)
+	}
+}
+
+// watchPlayer publishes track changes and play/pause transitions of p to
+// events, including the automatic switch to the next song, until events is
+// closed.
+func watchPlayer(p *playlist.Playlist, events *broker) {
+	var id string
+	var playing bool
+	ticker := time.NewTicker(playerPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-events.done:
+			return
+		case <-ticker.C:
+		}
+		song, elapsed, isPlaying := p.Current()
+		if song == nil {
+			continue
+		}
+		info := &ps.PlayerInfo{Title: song.Title, Duration: song.Duration, Elapsed: elapsed, Song: song}
+		switch {
+		case song.Id != id:
+			info.Event = EventTrackChanged
+		case isPlaying != playing && isPlaying:
+			info.Event = EventPlaying
+		case isPlaying != playing:
+			info.Event = EventPaused
+		default:
+			continue
+		}
+		id, playing = song.Id, isPlaying
+		events.publish(info)
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	ps "github.com/sgoldenf/playlist/api"
+	"golang.org/x/exp/slog"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"time"
+)
+
+// Services reported by the health server besides the overall status "" and
+// the PlaylistService itself, which both follow readiness.
+const (
+	HealthLiveness  = "liveness"
+	HealthReadiness = "readiness"
+)
+
+const (
+	healthCheckTimeout = 2 * time.Second
+	// stallTimeout is how long the play goroutine may go without a tick.
+	stallTimeout = 5 * time.Second
+)
+
+// Ready reports whether the service can answer requests: the playlist was
+// loaded and the database responds.
+func (s *PlaylistService) Ready(ctx context.Context) error {
+	select {
+	case <-s.events.done:
+		return errors.New("health error: server is shutting down")
+	default:
+	}
+	if !s.loaded {
+		return errors.New("health error: playlist is not loaded")
+	}
+	sqlDB, err := s.DB.DB()
+	if err != nil {
+		return fmt.Errorf("health error: %w", err)
+	}
+	if err = sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("health error: database: %w", err)
+	}
+	return nil
+}
+
+// Live reports whether the player works: its lock can be taken and the play
+// goroutine keeps advancing while playing.
+func (s *PlaylistService) Live(ctx context.Context) error {
+	if !s.P.Responsive(ctx) {
+		return errors.New("health error: playlist lock is held")
+	}
+	if s.P.Stalled(stallTimeout) {
+		return errors.New("health error: play goroutine is stuck")
+	}
+	return nil
+}
+
+// WatchHealth runs the checks every interval and reports the results to h
+// until ctx is done. Changes of a result are logged.
+func (s *PlaylistService) WatchHealth(ctx context.Context, h *health.Server, interval time.Duration) {
+	checks := []struct {
+		name     string
+		check    func(context.Context) error
+		services []string
+		last     string
+	}{
+		{name: HealthLiveness, check: s.Live, services: []string{HealthLiveness}},
+		{name: HealthReadiness, check: s.Ready, services: []string{"", HealthReadiness, ps.PlaylistService_ServiceDesc.ServiceName}},
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for i := range checks {
+			c := &checks[i]
+			checkCtx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+			err := c.check(checkCtx)
+			cancel()
+			status, result := healthpb.HealthCheckResponse_SERVING, "ok"
+			if err != nil {
+				status, result = healthpb.HealthCheckResponse_NOT_SERVING, err.Error()
+			}
+			if result != c.last && (c.last != "" || err != nil) {
+				if err != nil {
+					slog.Warn("health check failed", "check", c.name, "err", err)
+				} else {
+					slog.Info("health check recovered", "check", c.name)
+				}
+			}
+			c.last = result
+			for _, service := range c.services {
+				h.SetServingStatus(service, status)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -2,26 +2,50 @@ package server
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
+	"github.com/google/uuid"
 	ps "github.com/sgoldenf/playlist/api"
+	db "github.com/sgoldenf/playlist/db"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/party"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/test/bufconn"
 	"io"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
 
 func runTestServerClientConnection(ctx context.Context) (ps.PlaylistServiceClient, func()) {
+	client, _, closeListener := runTestServiceClientConnection(ctx)
+	return client, closeListener
+}
+
+func runTestServiceClientConnection(ctx context.Context) (ps.PlaylistServiceClient, *PlaylistService, func()) {
 	buffer := 1024 * 1024
 	lis := bufconn.Listen(buffer)
 
 	s := grpc.NewServer()
-	service, err := NewService()
+	service, err := NewService(nil, "http://localhost:8080")
 	if err != nil {
 		log.Fatalf("Failed to serve Database: %v", err)
 	}
@@ -50,32 +74,12 @@ func runTestServerClientConnection(ctx context.Context) (ps.PlaylistServiceClien
 
 	client := ps.NewPlaylistServiceClient(conn)
 
-	return client, closeListener
+	return client, service, closeListener
 }
 
 func printServerMessage(message *ps.PlayerInfo) {
 	if message != nil {
-		m := message.Elapsed / 60
-		s := message.Elapsed - m*60
-		em := strconv.FormatUint(m, 10)
-		es := strconv.FormatUint(s, 10)
-		if m < 10 {
-			em = "0" + em
-		}
-		if s < 10 {
-			es = "0" + es
-		}
-		m = message.Duration / 60
-		s = message.Duration - m*60
-		dm := strconv.FormatUint(m, 10)
-		ds := strconv.FormatUint(s, 10)
-		if m < 10 {
-			dm = "0" + dm
-		}
-		if s < 10 {
-			ds = "0" + ds
-		}
-		fmt.Printf("%s:%s/%s:%s - %s\n", em, es, dm, ds, message.Title)
+		fmt.Println(cli.FormatPlayerInfo(message))
 	}
 }
 
@@ -199,6 +203,7 @@ func TestPlaylistService_UpdateSong(t *testing.T) {
 	reqSong := res.Songs[len(res.Songs)-1]
 
 	testSong := &ps.SongInfo{
+		Id:       reqSong.Id,
 		Title:    "Dave Brubeck Quartet - Blue Rondo A La Turk",
 		Duration: 405,
 	}
@@ -249,6 +254,32 @@ func TestPlaylistService_UpdateSong(t *testing.T) {
 	}
 }
 
+func TestPlaylistService_UpdateSongPlayers(t *testing.T) {
+	ctx := context.Background()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	created, err := client.CreateSong(ctx, &ps.CreateSongRequest{Song: &ps.SongInfo{
+		Title: "Take Five", Duration: 324, Artist: "Dave Brubeck",
+	}})
+	if err != nil {
+		t.Fatalf("create song error: %v", err)
+	}
+	defer client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: created.Song.Id})
+	if _, err = client.UpdateSong(ctx, &ps.UpdateSongRequest{Song: &ps.SongInfo{Id: created.Song.Id, Title: "Take 5"}}); err != nil {
+		t.Fatalf("update song error: %v", err)
+	}
+	for _, song := range service.P.Songs() {
+		if song.Id == created.Song.Id {
+			if song.Title != "Take 5" || song.Duration != 324 || song.Artist != "Dave Brubeck" {
+				t.Errorf("Out -> \nWant: %v\nGot : %v", "Take 5 by Dave Brubeck, 324s", song)
+			}
+			return
+		}
+	}
+	t.Errorf("song %s is not in the playlist", created.Song.Id)
+}
+
 func TestPlaylistService_DeleteSong(t *testing.T) {
 	ctx := context.Background()
 	client, closeListener := runTestServerClientConnection(ctx)
@@ -368,6 +399,9 @@ func TestPlaylistService_Player(t *testing.T) {
 					if errors.Is(errStream, io.EOF) {
 						break
 					}
+					if message.GetEvent() != "" {
+						continue
+					}
 					messages = append(messages, message)
 				}
 			}()
@@ -413,3 +447,883 @@ func TestPlaylistService_Player(t *testing.T) {
 		})
 	}
 }
+
+func TestPlaylistService_PlayerResume(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, closeListener := runTestServerClientConnection(ctx)
+	defer closeListener()
+
+	stream, err := client.Player(ctx, &ps.ConnectRequest{})
+	if err != nil {
+		t.Fatalf("player error: %v", err)
+	}
+	nextEvent := func(stream ps.PlaylistService_PlayerClient) *ps.PlayerInfo {
+		for {
+			message, errStream := stream.Recv()
+			if errStream != nil {
+				t.Fatalf("player stream error: %v", errStream)
+			}
+			if message.Event != "" {
+				return message
+			}
+		}
+	}
+	if _, err = client.Play(ctx, &ps.PlayRequest{}); err != nil {
+		t.Fatalf("play error: %v", err)
+	}
+	changed := nextEvent(stream)
+	if _, err = client.Pause(ctx, &ps.PauseRequest{}); err != nil {
+		t.Fatalf("pause error: %v", err)
+	}
+	paused := nextEvent(stream)
+	if changed.Event != EventTrackChanged || changed.Song == nil || paused.Event != EventPaused || paused.EventId <= changed.EventId {
+		t.Errorf("Out -> \nWant: %s, %s\nGot : %v, %v", EventTrackChanged, EventPaused, changed, paused)
+	}
+
+	resumed, err := client.Player(ctx, &ps.ConnectRequest{LastEventId: changed.EventId})
+	if err != nil {
+		t.Fatalf("player error: %v", err)
+	}
+	if missed := nextEvent(resumed); missed.EventId != paused.EventId || missed.Event != EventPaused {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", paused, missed)
+	}
+}
+
+func TestPlaylistService_MoveSong(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, closeListener := runTestServerClientConnection(ctx)
+	defer closeListener()
+
+	res, err := client.GetPlaylist(ctx, &ps.GetPlaylistRequest{})
+	if err != nil {
+		t.Fatalf("get playlist error: %v", err)
+	}
+	if len(res.Songs) < 2 {
+		t.Skip("need at least two songs in the database")
+	}
+	first := res.Songs[0]
+	last := uint64(len(res.Songs) - 1)
+	if _, err = client.MoveSong(ctx, &ps.MoveSongRequest{Id: first.Id, Position: last}); err != nil {
+		t.Fatalf("move error: %v", err)
+	}
+	if res, err = client.GetPlaylist(ctx, &ps.GetPlaylistRequest{}); err != nil || res.Songs[last].Id != first.Id {
+		t.Errorf("Out -> \nWant: %v last\nGot : %v (%v)", first.Id, res.GetSongs(), err)
+	}
+	if _, err = client.MoveSong(ctx, &ps.MoveSongRequest{Id: first.Id, Position: last + 1}); err == nil ||
+		!strings.Contains(err.Error(), playlist.ErrMoveOutOfRange.Error()) {
+		t.Errorf("expected %v, got %v", playlist.ErrMoveOutOfRange, err)
+	}
+	if _, err = client.MoveSong(ctx, &ps.MoveSongRequest{Id: uuid.New().String()}); err == nil {
+		t.Errorf("expected an error for an unknown song")
+	}
+	if _, err = client.MoveSong(ctx, &ps.MoveSongRequest{Id: first.Id}); err != nil {
+		t.Fatalf("move error: %v", err)
+	}
+}
+
+func TestPlaylistService_Party(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+	defer service.P.Pause()
+
+	if _, err := client.GetPartyQueue(ctx, &ps.GetPartyQueueRequest{}); err == nil {
+		t.Errorf("expected an error without party mode")
+	}
+	service.EnableParty(party.NewQueue(party.Limit{}, party.Limit{}))
+	ids := make([]string, 3)
+	for i, duration := range []uint64{1, 100, 100} {
+		res, err := client.CreateSong(ctx, &ps.CreateSongRequest{Song: &ps.SongInfo{Title: "Party " + strconv.Itoa(i), Duration: duration}})
+		if err != nil {
+			t.Fatalf("create error: %v", err)
+		}
+		ids[i] = res.Song.Id
+		defer client.DeleteSong(context.Background(), &ps.DeleteSongRequest{Id: res.Song.Id})
+	}
+	// Replay from the last event, the stream may subscribe after the calls
+	// below.
+	state, err := client.GetPlayerState(ctx, &ps.GetPlayerStateRequest{})
+	if err != nil {
+		t.Fatalf("player state error: %v", err)
+	}
+	stream, err := client.Player(ctx, &ps.ConnectRequest{LastEventId: state.LastEventId})
+	if err != nil {
+		t.Fatalf("player error: %v", err)
+	}
+	alice := auth.NewContext(ctx, &auth.Principal{Subject: "alice"})
+	bob := auth.NewContext(ctx, &auth.Principal{Subject: "bob"})
+	if _, err = service.SuggestSong(alice, &ps.SuggestSongRequest{Id: ids[1]}); err != nil {
+		t.Fatalf("suggest error: %v", err)
+	}
+	if _, err = service.SuggestSong(alice, &ps.SuggestSongRequest{Id: ids[2]}); err != nil {
+		t.Fatalf("suggest error: %v", err)
+	}
+	queue, err := service.VoteSong(bob, &ps.VoteSongRequest{Id: ids[2], Vote: 1})
+	if err != nil {
+		t.Fatalf("vote error: %v", err)
+	}
+	if len(queue.Entries) != 2 || queue.Entries[0].Song.Id != ids[2] || queue.Entries[0].Score != 2 || queue.Entries[0].Vote != 1 {
+		t.Errorf("unexpected party queue %v", queue.Entries)
+	}
+	if err = service.P.PlaySong(ids[0]); err != nil {
+		t.Fatalf("play error: %v", err)
+	}
+
+	// The short song ends after a second and the top-voted suggestion
+	// follows it.
+	var changes []*ps.PlayerInfo
+	for {
+		message, errStream := stream.Recv()
+		if errStream != nil {
+			t.Fatalf("player stream error: %v", errStream)
+		}
+		if message.Event == EventPartyChanged {
+			changes = append(changes, message)
+		}
+		if message.Event == EventTrackChanged && message.Song.Id != ids[0] {
+			if message.Song.Id != ids[2] {
+				t.Errorf("Out -> \nWant: %v\nGot : %v", ids[2], message.Song.Id)
+			}
+			break
+		}
+	}
+	if len(changes) != 4 {
+		t.Fatalf("Out -> \nWant: %v\nGot : %v", 4, len(changes))
+	}
+	if last := changes[3].PartyQueue; len(last) != 1 || last[0].Song.Id != ids[1] {
+		t.Errorf("Out -> \nWant: %v left\nGot : %v", ids[1], last)
+	}
+}
+
+func TestBroker_Replay(t *testing.T) {
+	b := newBroker()
+	for i := 0; i < replayBuffer+44; i++ {
+		b.publish(&ps.PlayerInfo{Event: EventSongAdded})
+	}
+	ch, replay := b.subscribe(10)
+	defer b.unsubscribe(ch)
+	if len(replay) != replayBuffer || replay[0].EventId != 45 || replay[len(replay)-1].EventId != replayBuffer+44 {
+		t.Errorf("unexpected replay of %d events starting at %d", len(replay), replay[0].EventId)
+	}
+	if _, replay = b.subscribe(0); len(replay) != 0 {
+		t.Errorf("expected no replay for a new client, got %d events", len(replay))
+	}
+	if _, replay = b.subscribe(replayBuffer + 43); len(replay) != 1 {
+		t.Errorf("expected the last event only, got %d events", len(replay))
+	}
+	b.publish(&ps.PlayerInfo{Event: EventSongRemoved})
+	if info := <-ch; info.EventId != replayBuffer+45 || info.Event != EventSongRemoved {
+		t.Errorf("unexpected event %v", info)
+	}
+}
+
+func TestBroker_Close(t *testing.T) {
+	b := newBroker()
+	ch, _ := b.subscribe(0)
+	defer b.unsubscribe(ch)
+	b.close(&ps.PlayerInfo{Event: EventShutdown})
+	b.close(&ps.PlayerInfo{Event: EventShutdown})
+	select {
+	case <-b.done:
+	default:
+		t.Errorf("done is not closed")
+	}
+	if info := <-ch; info.Event != EventShutdown || len(ch) != 0 {
+		t.Errorf("expected a single %s event, got %v and %d more", EventShutdown, info, len(ch))
+	}
+}
+
+func TestPlaylistService_Shutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	stream, err := client.Player(ctx, &ps.ConnectRequest{})
+	if err != nil {
+		t.Fatalf("player error: %v", err)
+	}
+	if _, err = client.Play(ctx, &ps.PlayRequest{}); err != nil {
+		t.Fatalf("play error: %v", err)
+	}
+	if _, err = stream.Recv(); err != nil {
+		t.Fatalf("player stream error: %v", err)
+	}
+	service.Shutdown()
+	var last *ps.PlayerInfo
+	for {
+		message, errStream := stream.Recv()
+		if errStream == io.EOF {
+			break
+		}
+		if errStream != nil {
+			t.Fatalf("player stream error: %v", errStream)
+		}
+		last = message
+	}
+	if last == nil || last.Event != EventShutdown {
+		t.Errorf("Out -> \nWant: %s\nGot : %v", EventShutdown, last)
+	}
+	if _, _, playing := service.P.Current(); playing {
+		t.Errorf("player is still playing after shutdown")
+	}
+	if stream, err = client.Player(ctx, &ps.ConnectRequest{}); err == nil {
+		if _, err = stream.Recv(); err != io.EOF {
+			t.Errorf("expected a new stream to end at once, got %v", err)
+		}
+	}
+}
+
+func TestPlaylistService_SaveState(t *testing.T) {
+	service, err := NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	defer service.Close()
+	songs := service.P.Songs()
+	if len(songs) < 2 {
+		t.Skip("need at least two songs in the database")
+	}
+	defer service.DB.Delete(&db.PlayerState{ID: stateID})
+	if err = service.P.Move(songs[0].Id, len(songs)-1); err != nil {
+		t.Fatalf("move error: %v", err)
+	}
+	if err = service.P.Restore(songs[1].Id, 1); err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	if err = service.SaveState(context.Background()); err != nil {
+		t.Fatalf("save error: %v", err)
+	}
+
+	restored, err := NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	defer restored.Close()
+	got := restored.P.Songs()
+	if len(got) != len(songs) || got[0].Id != songs[1].Id || got[len(got)-1].Id != songs[0].Id {
+		t.Errorf("Out -> \nWant: %v first, %v last\nGot : %v", songs[1].Id, songs[0].Id, got)
+	}
+	if song, elapsed, playing := restored.P.Current(); song == nil || song.Id != songs[1].Id || elapsed != 1 || playing {
+		t.Errorf("Out -> \nWant: %v paused at 1\nGot : %v %d %v", songs[1].Id, song, elapsed, playing)
+	}
+}
+
+func TestPlaylistService_Sessions(t *testing.T) {
+	service, err := NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	defer service.Close()
+	service.Sessions = NewSessions(time.Minute)
+	background := context.Background()
+	created, err := service.CreateSong(background, &ps.CreateSongRequest{Song: &ps.SongInfo{Title: "Session", Duration: 100}})
+	if err != nil {
+		t.Fatalf("create error: %v", err)
+	}
+	song := created.Song
+	defer service.DeleteSong(background, &ps.DeleteSongRequest{Id: song.Id})
+	user := "session-test-" + uuid.New().String()
+	defer service.DB.Delete(&db.User{ID: user})
+	alice := auth.NewContext(background, &auth.Principal{Subject: user})
+	bob := auth.NewContext(background, &auth.Principal{Subject: user + "-bob"})
+	defer service.DB.Delete(&db.User{ID: user + "-bob"})
+
+	if err = service.Playlist(alice).Restore(song.Id, 7); err != nil {
+		t.Fatalf("restore error: %v", err)
+	}
+	if got := service.ActiveSessions(); got != 1 {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", 1, got)
+	}
+	state, _ := service.GetPlayerState(bob, &ps.GetPlayerStateRequest{})
+	if state.Song != nil && state.Song.Id == song.Id && state.Elapsed == 7 {
+		t.Errorf("the player of one user changed the player of another")
+	}
+	if current, elapsed, _ := service.P.Current(); current != nil && current.Id == song.Id && elapsed == 7 {
+		t.Errorf("the player of a user changed the shared player")
+	}
+
+	added, err := service.CreateSong(background, &ps.CreateSongRequest{Song: &ps.SongInfo{Title: "Added", Duration: 50}})
+	if err != nil {
+		t.Fatalf("create error: %v", err)
+	}
+	defer service.DeleteSong(background, &ps.DeleteSongRequest{Id: added.Song.Id})
+	playlistRes, _ := service.GetPlaylist(alice, &ps.GetPlaylistRequest{})
+	if songs := playlistRes.Songs; len(songs) == 0 || songs[len(songs)-1].Id != added.Song.Id {
+		t.Errorf("a song created during a session is not in its playlist")
+	}
+
+	if err = service.Playlist(bob).PlaySong(added.Song.Id); err != nil {
+		t.Fatalf("play error: %v", err)
+	}
+	if _, err = service.DeleteSong(background, &ps.DeleteSongRequest{Id: added.Song.Id}); !errors.Is(err, playlist.ErrSongIsPlaying) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", playlist.ErrSongIsPlaying, err)
+	}
+	service.Playlist(bob).Pause()
+
+	service.Sessions.IdleTimeout = 0
+	time.Sleep(time.Millisecond)
+	service.EvictIdleSessions(background)
+	if got := service.ActiveSessions(); got != 0 {
+		t.Fatalf("Out -> \nWant: %v\nGot : %v", 0, got)
+	}
+	var account db.User
+	if err = service.DB.First(&account, "id = ?", user).Error; err != nil {
+		t.Fatalf("user error: %v", err)
+	}
+	if account.SongID != song.Id || account.Elapsed != 7 {
+		t.Errorf("Out -> \nWant: %v at 7\nGot : %v at %d", song.Id, account.SongID, account.Elapsed)
+	}
+
+	service.Sessions.IdleTimeout = time.Minute
+	state, _ = service.GetPlayerState(alice, &ps.GetPlayerStateRequest{})
+	if state.Song == nil || state.Song.Id != song.Id || state.Elapsed != 7 || state.Playing {
+		t.Errorf("Out -> \nWant: %v paused at 7\nGot : %v", song.Id, state)
+	}
+}
+
+func TestPlaylistService_SessionCatchUp(t *testing.T) {
+	service := &PlaylistService{P: playlist.NewPlaylist([]*ps.SongInfo{{Id: "uuid1", Title: "song1", Duration: 4}}), events: newBroker()}
+	ss := &session{P: playlist.NewPlaylist([]*ps.SongInfo{
+		{Id: "uuid1", Title: "old", Duration: 4},
+		{Id: "uuid2", Title: "deleted", Duration: 3},
+	})}
+	service.P.AddSong(&ps.SongInfo{Id: "uuid3", Title: "added", Duration: 2})
+	service.catchUp(ss)
+	var got []string
+	for _, song := range ss.P.Songs() {
+		got = append(got, song.Title)
+	}
+	if want := []string{"song1", "added"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+	}
+}
+
+func TestSessions_Idle(t *testing.T) {
+	sessions := NewSessions(time.Minute)
+	now := time.Now()
+	for user, used := range map[string]time.Duration{"active": time.Second, "idle": 2 * time.Minute, "streaming": time.Hour} {
+		ss := &session{user: user}
+		ss.used.Store(now.Add(-used).UnixNano())
+		sessions.users[user] = ss
+	}
+	sessions.users["streaming"].streams.Add(1)
+	evicted := sessions.idle(now)
+	if len(evicted) != 1 || evicted[0].user != "idle" {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", "idle", evicted)
+	}
+	if got := sessions.Len(); got != 2 {
+		t.Errorf("Out -> \nWant: %v\nGot : %v", 2, got)
+	}
+}
+
+func TestPlaylistService_ReadyLive(t *testing.T) {
+	service := &PlaylistService{P: playlist.NewPlaylist(nil), events: newBroker()}
+	ctx := context.Background()
+	if err := service.Ready(ctx); err == nil || !strings.Contains(err.Error(), "not loaded") {
+		t.Errorf("expected a not loaded playlist to be not ready, got %v", err)
+	}
+	if err := service.Live(ctx); err != nil {
+		t.Errorf("Live() = %v", err)
+	}
+	service.Shutdown()
+	if err := service.Ready(ctx); err == nil || !strings.Contains(err.Error(), "shutting down") {
+		t.Errorf("expected a shut down service to be not ready, got %v", err)
+	}
+}
+
+func TestPlaylistService_WatchHealth(t *testing.T) {
+	service, err := NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	defer service.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := health.NewServer()
+	go service.WatchHealth(ctx, h, 10*time.Millisecond)
+
+	waitStatus := func(name string, want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		deadline := time.Now().Add(3 * time.Second)
+		for {
+			res, errCheck := h.Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+			if errCheck == nil && res.Status == want {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Out -> \nWant: %q %v\nGot : %v %v", name, want, res, errCheck)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+	for _, name := range []string{"", HealthReadiness, HealthLiveness, ps.PlaylistService_ServiceDesc.ServiceName} {
+		waitStatus(name, healthpb.HealthCheckResponse_SERVING)
+	}
+	service.Shutdown()
+	waitStatus(HealthReadiness, healthpb.HealthCheckResponse_NOT_SERVING)
+	waitStatus(HealthLiveness, healthpb.HealthCheckResponse_SERVING)
+}
+
+func TestPlaylistService_Tracing(t *testing.T) {
+	exporter := tracing.InstallLocal()
+	service, err := NewService(nil, "http://localhost:8080")
+	if err != nil {
+		t.Fatalf("Failed to serve Database: %v", err)
+	}
+	if err = service.DB.Use(tracing.GORM()); err != nil {
+		t.Fatal(err)
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer(grpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()))
+	ps.RegisterPlaylistServiceServer(s, service)
+	go s.Serve(lis)
+	defer s.Stop()
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	ctx, caller := otel.Tracer("test").Start(context.Background(), "caller")
+	res, err := ps.NewPlaylistServiceClient(conn).CreateSong(ctx, &ps.CreateSongRequest{Song: &ps.SongInfo{Title: "Traced", Duration: 10}})
+	caller.End()
+	if err != nil {
+		t.Fatalf("create error: %v", err)
+	}
+	defer service.DeleteSong(context.Background(), &ps.DeleteSongRequest{Id: res.Song.Id})
+
+	// The client and the server span of the call share the name, the
+	// other spans are keyed by name.
+	var rpc *tracetest.SpanStub
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		switch {
+		case span.SpanContext.TraceID() != caller.SpanContext().TraceID():
+		case span.SpanKind == trace.SpanKindServer:
+			server := span
+			rpc = &server
+		default:
+			spans[span.Name] = span
+		}
+	}
+	if rpc == nil || rpc.Name != ps.PlaylistService_ServiceDesc.ServiceName+"/CreateSong" {
+		t.Fatalf("no server span in the caller's trace: %v", spans)
+	}
+	if !rpc.Parent.IsRemote() {
+		t.Errorf("trace context was not propagated: %v", rpc.Parent)
+	}
+	for _, name := range []string{"db.create", "playlist.AddSong"} {
+		if span, ok := spans[name]; !ok || span.Parent.SpanID() != rpc.SpanContext.SpanID() {
+			t.Errorf("%s -> \nWant: child of %v\nGot : %v", name, rpc.SpanContext.SpanID(), span.Parent.SpanID())
+		}
+	}
+}
+
+func TestPlaylistService_ImportSongs(t *testing.T) {
+	ctx := context.Background()
+	client, closeListener := runTestServerClientConnection(ctx)
+	defer closeListener()
+
+	res, err := client.GetSongs(ctx, &ps.ReadSongsRequest{})
+	if err != nil {
+		t.Errorf("GetSongsError:\nexpected err == nil, got:\n%v", err)
+	}
+	existing := res.Songs[0]
+	unique := uuid.New().String()
+
+	tests := map[string]struct {
+		format   string
+		chunks   []string
+		expected *ps.ImportSongsResponse
+	}{
+		"csv": {
+			format: "csv",
+			chunks: []string{
+				"title,duration\nTake Five " + unique + ",325\n",
+				"Take Five " + unique + ",325\n,100\nSo What " + unique + ",5",
+				"45\n" + existing.Title + "," + strconv.FormatUint(existing.Duration, 10) + "\nbroken,abc\n",
+			},
+			expected: &ps.ImportSongsResponse{Total: 6, Created: 2, Duplicates: 2, Invalid: 2, DryRun: true},
+		},
+		"ndjson": {
+			format: "ndjson",
+			chunks: []string{
+				"{\"title\": \"Take Five " + unique + "\", \"duration\": 325}\n\n",
+				"{\"title\": \"\", \"duration\": 10}\n{\"title\": 1}\n",
+			},
+			expected: &ps.ImportSongsResponse{Total: 3, Created: 1, Invalid: 2, DryRun: true},
+		},
+	}
+	for caseName, test := range tests {
+		t.Run(caseName, func(t *testing.T) {
+			stream, errStream := client.ImportSongs(ctx)
+			if errStream != nil {
+				t.Fatalf("import error: %v", errStream)
+			}
+			for i, chunk := range test.chunks {
+				req := &ps.ImportSongsRequest{Data: []byte(chunk)}
+				if i == 0 {
+					req.Format = test.format
+					req.DryRun = true
+				}
+				if errSend := stream.Send(req); errSend != nil {
+					t.Fatalf("send error: %v", errSend)
+				}
+			}
+			report, errClose := stream.CloseAndRecv()
+			if errClose != nil {
+				t.Fatalf("import error: %v", errClose)
+			}
+			if report.Total != test.expected.Total || report.Created != test.expected.Created ||
+				report.Duplicates != test.expected.Duplicates || report.Invalid != test.expected.Invalid ||
+				report.DryRun != test.expected.DryRun || len(report.Errors) != int(report.Invalid) {
+				t.Errorf("Out -> \nWant: %v\nGot : %v", test.expected, report)
+			}
+		})
+	}
+	after, err := client.GetSongs(ctx, &ps.ReadSongsRequest{})
+	if err != nil {
+		t.Errorf("GetSongsError:\nexpected err == nil, got:\n%v", err)
+	} else if len(after.Songs) != len(res.Songs) {
+		t.Errorf("dry run changed library: %d songs before, %d after", len(res.Songs), len(after.Songs))
+	}
+}
+
+func TestPlaylistService_BatchSongs(t *testing.T) {
+	ctx := context.Background()
+	client, closeListener := runTestServerClientConnection(ctx)
+	defer closeListener()
+
+	unique := uuid.New().String()
+	songs := []*ps.SongInfo{
+		{Title: "Batch 1 " + unique, Duration: 100},
+		{Title: "", Duration: 100},
+		{Title: "Batch 2 " + unique, Duration: 200},
+	}
+
+	_, err := client.BatchCreateSongs(ctx, &ps.BatchCreateSongsRequest{Songs: songs, AllOrNothing: true})
+	if err == nil || err.Error() !=
+		"rpc error: code = Unknown desc = batch create error: song 1: create song error: empty title/duration==0" {
+		t.Errorf("Err -> \nWant: batch create error\nGot: %v\n", err)
+	}
+
+	created, err := client.BatchCreateSongs(ctx, &ps.BatchCreateSongsRequest{Songs: songs})
+	if err != nil {
+		t.Fatalf("batch create error: %v", err)
+	}
+	if len(created.Results) != 3 || !created.Results[0].Success || created.Results[1].Success ||
+		!created.Results[2].Success || created.Results[0].Song.Id == "" {
+		t.Fatalf("Out -> \nGot : %v", created)
+	}
+
+	ids := []string{created.Results[0].Song.Id, "invalid", created.Results[2].Song.Id}
+	_, err = client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: ids, AllOrNothing: true})
+	if err == nil || err.Error() != "rpc error: code = Unknown desc = batch delete error: invalid: song not found" {
+		t.Errorf("Err -> \nWant: batch delete error\nGot: %v\n", err)
+	}
+	for _, id := range []string{ids[0], ids[2]} {
+		if _, errGet := client.GetSong(ctx, &ps.ReadSongRequest{Id: id}); errGet != nil {
+			t.Errorf("song %s deleted by failed batch: %v", id, errGet)
+		}
+	}
+
+	deleted, err := client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: ids})
+	if err != nil {
+		t.Fatalf("batch delete error: %v", err)
+	}
+	if len(deleted.Results) != 3 || !deleted.Results[0].Success || deleted.Results[1].Success ||
+		!deleted.Results[2].Success {
+		t.Errorf("Out -> \nGot : %v", deleted)
+	}
+	for _, id := range []string{ids[0], ids[2]} {
+		if _, errGet := client.GetSong(ctx, &ps.ReadSongRequest{Id: id}); errGet == nil {
+			t.Errorf("song %s was not deleted", id)
+		}
+	}
+}
+
+func writeTestWAV(t *testing.T, path string, seconds int) {
+	wav := make([]byte, 44+8000*seconds)
+	copy(wav, "RIFF")
+	binary.LittleEndian.PutUint32(wav[4:], uint32(len(wav)-8))
+	copy(wav[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(wav[16:], 16)
+	binary.LittleEndian.PutUint16(wav[20:], 1)
+	binary.LittleEndian.PutUint16(wav[22:], 1)
+	binary.LittleEndian.PutUint32(wav[24:], 8000)
+	binary.LittleEndian.PutUint32(wav[28:], 8000)
+	binary.LittleEndian.PutUint16(wav[32:], 1)
+	binary.LittleEndian.PutUint16(wav[34:], 8)
+	copy(wav[36:], "data")
+	binary.LittleEndian.PutUint32(wav[40:], uint32(8000*seconds))
+	if err := os.WriteFile(path, wav, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPlaylistService_BatchDeletePlaying(t *testing.T) {
+	ctx := context.Background()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	unique := uuid.New().String()
+	created, err := client.BatchCreateSongs(ctx, &ps.BatchCreateSongsRequest{Songs: []*ps.SongInfo{
+		{Title: "Playing " + unique, Duration: 100},
+		{Title: "Idle " + unique, Duration: 100},
+	}})
+	if err != nil {
+		t.Fatalf("batch create error: %v", err)
+	}
+	playing, idle := created.Results[0].Song.Id, created.Results[1].Song.Id
+	if err = service.P.PlaySong(playing); err != nil {
+		t.Fatalf("play error: %v", err)
+	}
+	deleted, err := client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: []string{playing, idle}})
+	service.P.Pause()
+	defer client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: playing})
+	if err != nil {
+		t.Fatalf("batch delete error: %v", err)
+	}
+	if len(deleted.Results) != 2 || deleted.Results[0].Success || deleted.Results[0].Error != playlist.ErrSongIsPlaying.Error() ||
+		!deleted.Results[1].Success {
+		t.Errorf("Out -> \nGot : %v", deleted)
+	}
+	if _, err = client.GetSong(ctx, &ps.ReadSongRequest{Id: playing}); err != nil {
+		t.Errorf("the playing song was deleted: %v", err)
+	}
+}
+
+func TestPlaylistService_CreateSongFromFile(t *testing.T) {
+	ctx := context.Background()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	dir := t.TempDir()
+	service.Scanner.Dirs = []string{dir}
+	path := filepath.Join(dir, "Bill Evans - Peace Piece.wav")
+	writeTestWAV(t, path, 125)
+
+	res, err := client.CreateSongFromFile(ctx, &ps.CreateSongFromFileRequest{Path: path})
+	if err != nil {
+		t.Fatalf("create song from file error: %v", err)
+	}
+	if res.Song.Title != "Bill Evans - Peace Piece" || res.Song.Duration != 125 || res.Song.Id == "" {
+		t.Errorf("Out -> \nGot : %v", res)
+	}
+	_, err = client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: res.Song.Id})
+	if err != nil {
+		t.Errorf("delete error: %v", err)
+	}
+
+	_, err = client.CreateSongFromFile(ctx, &ps.CreateSongFromFileRequest{Path: filepath.Join(dir, "missing.mp3")})
+	if err == nil {
+		t.Errorf("expected error for missing file")
+	}
+
+	outside := filepath.Join(t.TempDir(), "Bill Evans - Waltz for Debby.wav")
+	writeTestWAV(t, outside, 125)
+	for _, p := range []string{outside, filepath.Join(dir, "..", filepath.Base(filepath.Dir(outside)), filepath.Base(outside))} {
+		_, err = client.CreateSongFromFile(ctx, &ps.CreateSongFromFileRequest{Path: p})
+		if err == nil || !strings.Contains(err.Error(), ErrOutsideLibrary.Error()) {
+			t.Errorf("%s -> \nWant: %v\nGot : %v", p, ErrOutsideLibrary, err)
+		}
+	}
+}
+
+func collectScan(ctx context.Context, t *testing.T, client ps.PlaylistServiceClient) (map[string]string, *ps.ScanProgress) {
+	stream, err := client.ScanLibrary(ctx, &ps.ScanLibraryRequest{})
+	if err != nil {
+		t.Fatalf("scan error: %v", err)
+	}
+	actions := make(map[string]string)
+	for {
+		progress, errRecv := stream.Recv()
+		if errRecv != nil {
+			t.Fatalf("scan error: %v", errRecv)
+		}
+		if progress.Done {
+			return actions, progress
+		}
+		actions[filepath.Base(progress.Path)] = progress.Action
+	}
+}
+
+func TestPlaylistService_ScanLibrary(t *testing.T) {
+	ctx := context.Background()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	dir := t.TempDir()
+	service.Scanner.Dirs = []string{dir}
+	writeWAV := func(name string, seconds int) {
+		writeTestWAV(t, filepath.Join(dir, name), seconds)
+	}
+	writeWAV("first.wav", 3)
+	writeWAV("second.wav", 4)
+	writeWAV("empty.wav", 0)
+	if err := os.WriteFile(filepath.Join(dir, "cover.jpg"), []byte("jpeg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	actions, total := collectScan(ctx, t, client)
+	if actions["first.wav"] != "added" || actions["second.wav"] != "added" || actions["empty.wav"] != "failed" ||
+		total.Added != 2 || total.Failed != 1 || total.Scanned != 3 {
+		t.Errorf("first scan -> %v %v", actions, total)
+	}
+
+	writeWAV("first.wav", 5)
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, "first.wav"), later, later); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "second.wav")); err != nil {
+		t.Fatal(err)
+	}
+	actions, total = collectScan(ctx, t, client)
+	if actions["first.wav"] != "updated" || actions["second.wav"] != "removed" || total.Updated != 1 || total.Removed != 1 {
+		t.Errorf("second scan -> %v %v", actions, total)
+	}
+
+	var songs []*ps.SongInfo
+	service.DB.Where("path LIKE ?", dir+"%").Find(&songs)
+	ids := make([]string, 0, len(songs))
+	for _, song := range songs {
+		if filepath.Base(song.Path) == "first.wav" && (song.Duration != 5 || song.Missing) {
+			t.Errorf("first.wav was not updated: %v", song)
+		}
+		if filepath.Base(song.Path) == "second.wav" && !song.Missing {
+			t.Errorf("second.wav was not marked missing: %v", song)
+		}
+		ids = append(ids, song.Id)
+	}
+	if _, err := client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: ids}); err != nil {
+		t.Errorf("cleanup error: %v", err)
+	}
+}
+
+func TestPlaylistService_WatchLibrary(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	dir := t.TempDir()
+	service.Scanner.Dirs = []string{dir}
+	watcher := &library.Watcher{Scanner: service.Scanner, Debounce: 50 * time.Millisecond}
+	go func() {
+		if err := watcher.Run(ctx); err != nil {
+			t.Errorf("watch error: %v", err)
+		}
+	}()
+
+	stream, err := client.Player(ctx, &ps.ConnectRequest{})
+	if err != nil {
+		t.Fatalf("player error: %v", err)
+	}
+	events := make(chan *ps.PlayerInfo, 16)
+	go func() {
+		for {
+			message, errStream := stream.Recv()
+			if errStream != nil {
+				close(events)
+				return
+			}
+			if message.Event != "" {
+				events <- message
+			}
+		}
+	}()
+	next := func(expected string) *ps.SongInfo {
+		select {
+		case message := <-events:
+			if message.Event != expected {
+				t.Fatalf("Out -> \nWant: %s\nGot : %v", expected, message)
+			}
+			return message.Song
+		case <-time.After(3 * time.Second):
+			t.Fatalf("timeout waiting for %s", expected)
+		}
+		return nil
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	writeTestWAV(t, filepath.Join(dir, "new.wav"), 2)
+	added := next(EventSongAdded)
+	if added.Duration != 2 || added.Title != "new" {
+		t.Errorf("unexpected song added: %v", added)
+	}
+
+	if err = os.Rename(filepath.Join(dir, "new.wav"), filepath.Join(dir, "renamed.wav")); err != nil {
+		t.Fatal(err)
+	}
+	next(EventSongRemoved)
+	moved := next(EventSongUpdated)
+	if moved.Id != added.Id || filepath.Base(moved.Path) != "renamed.wav" {
+		t.Errorf("rename was not tracked: %v -> %v", added, moved)
+	}
+
+	if err = os.Remove(filepath.Join(dir, "renamed.wav")); err != nil {
+		t.Fatal(err)
+	}
+	if removed := next(EventSongRemoved); removed.Id != added.Id || !removed.Missing {
+		t.Errorf("unexpected song removed: %v", removed)
+	}
+	if _, err = client.BatchDeleteSongs(ctx, &ps.BatchDeleteSongsRequest{Ids: []string{added.Id}}); err != nil {
+		t.Errorf("cleanup error: %v", err)
+	}
+}
+
+func TestPlaylistService_GetStreamURL(t *testing.T) {
+	ctx := context.Background()
+	client, service, closeListener := runTestServiceClientConnection(ctx)
+	defer closeListener()
+
+	dir := t.TempDir()
+	service.Scanner.Dirs = []string{dir}
+	path := filepath.Join(dir, "stream.wav")
+	writeTestWAV(t, path, 2)
+	created, err := client.CreateSongFromFile(ctx, &ps.CreateSongFromFileRequest{Path: path})
+	if err != nil {
+		t.Fatalf("create song from file error: %v", err)
+	}
+	defer client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: created.Song.Id})
+
+	res, err := client.GetStreamURL(ctx, &ps.GetStreamURLRequest{Id: created.Song.Id, Ttl: 60})
+	if err != nil {
+		t.Fatalf("get stream url error: %v", err)
+	}
+	if !strings.Contains(res.Url, "/v1/stream/"+created.Song.Id+"?") ||
+		res.Expires < time.Now().Unix()+55 || res.Expires > time.Now().Unix()+65 || res.HlsUrl != "" {
+		t.Errorf("Out -> \nGot : %v", res)
+	}
+
+	songs, err := client.GetSongs(ctx, &ps.ReadSongsRequest{})
+	if err != nil {
+		t.Fatalf("GetSongsError:\nexpected err == nil, got:\n%v", err)
+	}
+	_, err = client.GetStreamURL(ctx, &ps.GetStreamURLRequest{Id: songs.Songs[0].Id})
+	if err == nil || err.Error() != "rpc error: code = Unknown desc = stream error: song has no audio file" {
+		t.Errorf("Err -> \nWant: stream error\nGot: %v\n", err)
+	}
+
+	forged, err := client.CreateSong(ctx, &ps.CreateSongRequest{Song: &ps.SongInfo{
+		Title: "passwd", Duration: 1, Path: "/etc/passwd", Mtime: 1, Hash: "hash", Missing: true,
+	}})
+	if err != nil {
+		t.Fatalf("create song error: %v", err)
+	}
+	defer client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: forged.Song.Id})
+	if forged.Song.Path != "" || forged.Song.Mtime != 0 || forged.Song.Hash != "" || forged.Song.Missing {
+		t.Errorf("Out -> \nWant: no file fields\nGot : %v", forged.Song)
+	}
+	if _, err = client.GetStreamURL(ctx, &ps.GetStreamURLRequest{Id: forged.Song.Id}); err == nil {
+		t.Errorf("Err -> \nWant: stream error\nGot: %v\n", err)
+	}
+}
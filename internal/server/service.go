@@ -3,17 +3,39 @@ package server
 import (
 	ps "github.com/sgoldenf/playlist/api"
 	db "github.com/sgoldenf/playlist/db"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/library"
 	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/party"
+	"github.com/sgoldenf/playlist/internal/stream"
 	"gorm.io/gorm"
+	"sync/atomic"
 )
 
 type PlaylistService struct {
 	ps.UnimplementedPlaylistServiceServer
-	DB *gorm.DB
-	P  *playlist.Playlist
+	DB      *gorm.DB
+	P       *playlist.Playlist
+	Scanner *library.Scanner
+	Streams *stream.Signer
+	// Auth checks the credentials of HTTP requests, nil disables it.
+	Auth *auth.Authenticator
+	// Party is the queue of suggested songs the shared player picks from
+	// first, nil disables party mode. Set it with EnableParty.
+	Party *party.Queue
+	// Sessions gives each authenticated user a player of their own, nil
+	// lets everybody share P.
+	Sessions *Sessions
+	events   *broker
+	// loaded is set when Init read the songs from the database.
+	loaded bool
+	// players counts the connected Player streams.
+	players atomic.Int64
 }
 
-func NewService() (*PlaylistService, error) {
+// NewService connects to the database and starts the player. Stream URLs are
+// signed with streamSecret (a random one if empty) and point to baseURL.
+func NewService(streamSecret []byte, baseURL string) (*PlaylistService, error) {
 	database, errDB := db.New(db.PostgresConfig{
 		Host:     "localhost",
 		Port:     "5432",
@@ -24,7 +46,10 @@ func NewService() (*PlaylistService, error) {
 	if errDB != nil {
 		return nil, errDB
 	}
-	service := &PlaylistService{DB: database}
+	service := &PlaylistService{DB: database, events: newBroker()}
 	service.Init()
+	service.Scanner = &library.Scanner{DB: database, P: players{service}, OnChange: service.publishLibraryChange}
+	service.Streams = stream.NewSigner(streamSecret, baseURL)
+	go watchPlayer(service.P, service.events)
 	return service, nil
 }
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"gorm.io/gorm"
+)
+
+func (s *PlaylistService) BatchCreateSongs(ctx context.Context, req *ps.BatchCreateSongsRequest) (*ps.BatchCreateSongsResponse, error) {
+	results := make([]*ps.BatchResult, 0, len(req.GetSongs()))
+	valid := make([]*ps.SongInfo, 0, len(req.GetSongs()))
+	for i, info := range req.GetSongs() {
+		if err := validateSong(info); err != nil {
+			if req.GetAllOrNothing() {
+				return nil, fmt.Errorf("batch create error: song %d: %w", i, err)
+			}
+			results = append(results, &ps.BatchResult{Song: info, Error: err.Error()})
+			continue
+		}
+		info.Id = uuid.New().String()
+		clearFile(info)
+		valid = append(valid, info)
+		results = append(results, &ps.BatchResult{Song: info, Success: true})
+	}
+	if len(valid) > 0 {
+		err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			return tx.Create(&valid).Error
+		})
+		if err != nil {
+			return nil, fmt.Errorf("batch create error: %w", err)
+		}
+		_ = tracing.Do(ctx, "playlist.AddSongs", func(context.Context) error {
+			players{s}.AddSongs(valid)
+			return nil
+		})
+		for _, info := range valid {
+			s.publishLibraryChange(library.ActionAdded, info)
+		}
+	}
+	return &ps.BatchCreateSongsResponse{Results: results}, nil
+}
+
+func (s *PlaylistService) BatchDeleteSongs(ctx context.Context, req *ps.BatchDeleteSongsRequest) (*ps.BatchDeleteSongsResponse, error) {
+	var found []*ps.SongInfo
+	if err := s.DB.WithContext(ctx).Where("id IN ?", req.GetIds()).Find(&found).Error; err != nil {
+		return nil, fmt.Errorf("batch delete error: %w", err)
+	}
+	songs := make(map[string]*ps.SongInfo, len(found))
+	for _, song := range found {
+		songs[song.Id] = song
+	}
+	results := make([]*ps.BatchResult, 0, len(req.GetIds()))
+	ids := make([]string, 0, len(found))
+	for _, id := range req.GetIds() {
+		song, ok := songs[id]
+		var err error
+		if !ok {
+			err = ErrSongNotFound
+		} else if (players{s}).playing(id) {
+			err = playlist.ErrSongIsPlaying
+		}
+		if err != nil {
+			if req.GetAllOrNothing() {
+				return nil, fmt.Errorf("batch delete error: %s: %w", id, err)
+			}
+			results = append(results, &ps.BatchResult{Song: &ps.SongInfo{Id: id}, Error: err.Error()})
+			continue
+		}
+		delete(songs, id)
+		ids = append(ids, id)
+		results = append(results, &ps.BatchResult{Song: song, Success: true})
+	}
+	if len(ids) == 0 {
+		return &ps.BatchDeleteSongsResponse{Results: results}, nil
+	}
+	err := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id IN ?", ids).Delete(&ps.SongInfo{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(ids)) {
+			return errors.New("songs were changed concurrently")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("batch delete error: %w", err)
+	}
+	var kept map[string]bool
+	var undo func(restore func(id string) bool)
+	_ = tracing.Do(ctx, "playlist.DeleteSongs", func(context.Context) error {
+		kept, undo = players{s}.DeleteSongs(ids)
+		return nil
+	})
+	if len(kept) > 0 {
+		// Playback of a song started after it was checked. Its row comes back,
+		// with all_or_nothing the rows of the whole batch do, and the songs
+		// return to their places in the playlists.
+		restore := func(id string) bool {
+			return kept[id] || req.GetAllOrNothing()
+		}
+		var restored []*ps.SongInfo
+		for _, result := range results {
+			if result.Success && restore(result.Song.Id) {
+				restored = append(restored, result.Song)
+			}
+		}
+		if errRestore := s.DB.WithContext(ctx).Create(&restored).Error; errRestore != nil {
+			return nil, fmt.Errorf("batch delete error: restoring playing songs: %w", errRestore)
+		}
+		undo(restore)
+		if req.GetAllOrNothing() {
+			return nil, fmt.Errorf("batch delete error: %w", playlist.ErrSongIsPlaying)
+		}
+		for _, result := range results {
+			if result.Success && kept[result.Song.Id] {
+				result.Success, result.Error = false, playlist.ErrSongIsPlaying.Error()
+				result.Song = &ps.SongInfo{Id: result.Song.Id}
+			}
+		}
+	}
+	for _, result := range results {
+		if result.Success {
+			s.publishLibraryChange(library.ActionRemoved, &ps.SongInfo{Id: result.Song.Id})
+		}
+	}
+	return &ps.BatchDeleteSongsResponse{Results: results}, nil
+}
@@ -3,65 +3,146 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"github.com/google/uuid"
 	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"path/filepath"
 )
 
-func (s *PlaylistService) CreateSong(_ context.Context, req *ps.CreateSongRequest) (*ps.CreateSongResponse, error) {
-	info := req.GetSong()
-	if info.Title == "" || info.Duration == 0 {
-		return nil, errors.New("create song error: empty title/duration==0")
+var (
+	ErrInvalidSong  = errors.New("create song error: empty title/duration==0")
+	ErrSongNotFound = errors.New("song not found")
+	ErrNoSongs      = errors.New("songs not found")
+	// ErrOutsideLibrary keeps CreateSongFromFile from reading files that are
+	// not in the music directories.
+	ErrOutsideLibrary = errors.New("create song error: file is outside the music directories")
+)
+
+func validateSong(info *ps.SongInfo) error {
+	if info.GetTitle() == "" || info.GetDuration() == 0 {
+		return ErrInvalidSong
+	}
+	return nil
+}
+
+// clearFile drops the fields owned by the library scanner from a song sent by
+// a client, so that nobody but the scanner can point a song at a file.
+func clearFile(info *ps.SongInfo) {
+	info.Path, info.Mtime, info.Hash, info.Missing = "", 0, "", false
+}
+
+func (s *PlaylistService) CreateSong(ctx context.Context, req *ps.CreateSongRequest) (*ps.CreateSongResponse, error) {
+	if req.GetSong() != nil {
+		clearFile(req.Song)
+	}
+	return s.createSong(ctx, req.GetSong())
+}
+
+func (s *PlaylistService) createSong(ctx context.Context, info *ps.SongInfo) (*ps.CreateSongResponse, error) {
+	if err := validateSong(info); err != nil {
+		return nil, err
 	}
 	info.Id = uuid.New().String()
-	res := s.DB.Create(info)
+	res := s.DB.WithContext(ctx).Create(info)
 	if res.RowsAffected == 0 {
 		return nil, errors.New("song creation unsuccessful")
 	}
-	s.P.AddSong(info)
+	_ = tracing.Do(ctx, "playlist.AddSong", func(context.Context) error {
+		players{s}.AddSong(info)
+		return nil
+	})
+	s.publishLibraryChange(library.ActionAdded, info)
 	return &ps.CreateSongResponse{Song: info}, nil
 }
 
-func (s *PlaylistService) GetSong(_ context.Context, req *ps.ReadSongRequest) (*ps.ReadSongResponse, error) {
+func (s *PlaylistService) CreateSongFromFile(ctx context.Context, req *ps.CreateSongFromFileRequest) (*ps.CreateSongResponse, error) {
+	path, err := filepath.Abs(req.GetPath())
+	if err != nil {
+		return nil, fmt.Errorf("create song error: %w", err)
+	}
+	if s.Scanner == nil || !s.Scanner.Contains(path) {
+		return nil, ErrOutsideLibrary
+	}
+	song, err := library.SongFromFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("create song error: %w", err)
+	}
+	return s.createSong(ctx, song)
+}
+
+func (s *PlaylistService) GetSong(ctx context.Context, req *ps.ReadSongRequest) (*ps.ReadSongResponse, error) {
 	var song ps.SongInfo
-	res := s.DB.Find(&song, "id = ?", req.GetId())
+	res := s.DB.WithContext(ctx).Find(&song, "id = ?", req.GetId())
+	if res.Error != nil {
+		return nil, res.Error
+	}
 	if res.RowsAffected == 0 {
-		return nil, errors.New("song not found")
+		return nil, ErrSongNotFound
 	}
 	return &ps.ReadSongResponse{Song: &song}, nil
 }
 
-func (s *PlaylistService) GetSongs(context.Context, *ps.ReadSongsRequest) (*ps.ReadSongsResponse, error) {
+func (s *PlaylistService) GetSongs(ctx context.Context, _ *ps.ReadSongsRequest) (*ps.ReadSongsResponse, error) {
 	var songs []*ps.SongInfo
-	res := s.DB.Find(&songs)
+	res := s.DB.WithContext(ctx).Find(&songs)
+	if res.Error != nil {
+		return nil, res.Error
+	}
 	if res.RowsAffected == 0 {
-		return nil, errors.New("songs not found")
+		return nil, ErrNoSongs
 	}
 	return &ps.ReadSongsResponse{Songs: songs}, nil
 }
 
-func (s *PlaylistService) UpdateSong(_ context.Context, req *ps.UpdateSongRequest) (*ps.UpdateSongResponse, error) {
+func (s *PlaylistService) UpdateSong(ctx context.Context, req *ps.UpdateSongRequest) (*ps.UpdateSongResponse, error) {
 	var song ps.SongInfo
 	reqSong := req.GetSong()
-	res := s.DB.Model(&song).Where("id = ?", reqSong.Id).Updates(ps.SongInfo{
+	res := s.DB.WithContext(ctx).Model(&song).Where("id = ?", reqSong.Id).Updates(ps.SongInfo{
 		Title:    reqSong.Title,
 		Duration: reqSong.Duration,
+		Artist:   reqSong.Artist,
+		Album:    reqSong.Album,
 	})
+	if res.Error != nil {
+		return nil, res.Error
+	}
 	if res.RowsAffected == 0 {
-		return nil, errors.New("song not found")
+		return nil, ErrSongNotFound
+	}
+	// The request may leave fields out, the players, the event and the
+	// response get the whole stored row.
+	var stored ps.SongInfo
+	if err := s.DB.WithContext(ctx).First(&stored, "id = ?", reqSong.Id).Error; err != nil {
+		return nil, err
 	}
-	return &ps.UpdateSongResponse{Song: &song}, nil
+	_ = tracing.Do(ctx, "playlist.UpdateSong", func(context.Context) error {
+		players{s}.UpdateSong(&stored)
+		return nil
+	})
+	s.publishLibraryChange(library.ActionUpdated, &stored)
+	return &ps.UpdateSongResponse{Song: &stored}, nil
 }
 
-func (s *PlaylistService) DeleteSong(_ context.Context, req *ps.DeleteSongRequest) (*ps.DeleteSongResponse, error) {
+func (s *PlaylistService) DeleteSong(ctx context.Context, req *ps.DeleteSongRequest) (*ps.DeleteSongResponse, error) {
 	id := req.GetId()
-	if s.P.IsPlaying && s.P.Cur.Info.Id == id {
-		return &ps.DeleteSongResponse{Success: false}, errors.New("delete error: song is currently playing")
+	if (players{s}).playing(id) {
+		return &ps.DeleteSongResponse{Success: false}, playlist.ErrSongIsPlaying
 	}
-	s.P.DeleteSong(id)
+	_ = tracing.Do(ctx, "playlist.DeleteSong", func(context.Context) error {
+		players{s}.DeleteSong(id)
+		return nil
+	})
 	var song ps.SongInfo
-	res := s.DB.Where("id = ?", id).Delete(&song)
+	res := s.DB.WithContext(ctx).Where("id = ?", id).Delete(&song)
+	if res.Error != nil {
+		return nil, res.Error
+	}
 	if res.RowsAffected == 0 {
-		return nil, errors.New("song not found")
+		return nil, ErrSongNotFound
 	}
+	s.publishLibraryChange(library.ActionRemoved, &ps.SongInfo{Id: id})
 	return &ps.DeleteSongResponse{Success: true}, nil
 }
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/library"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gorm.io/gorm"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const importBatchSize = 500
+
+var ErrUnsupportedFormat = errors.New("import error: unsupported format")
+
+type rowError struct {
+	row uint64
+	err error
+}
+
+func (e *rowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.row, e.err)
+}
+
+type songDecoder interface {
+	// Next returns io.EOF when the input is exhausted and *rowError for a row
+	// that can be skipped; any other error aborts the import.
+	Next() (*ps.SongInfo, uint64, error)
+}
+
+func newSongDecoder(format string, r io.Reader) (songDecoder, error) {
+	switch strings.ToLower(format) {
+	case "", "csv":
+		cr := csv.NewReader(r)
+		cr.FieldsPerRecord = -1
+		cr.TrimLeadingSpace = true
+		return &csvDecoder{r: cr, title: 0, duration: 1}, nil
+	case "ndjson", "jsonl":
+		sc := bufio.NewScanner(r)
+		sc.Buffer(make([]byte, 64*1024), 1024*1024)
+		return &ndjsonDecoder{sc: sc}, nil
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnsupportedFormat, format)
+}
+
+type csvDecoder struct {
+	r         *csv.Reader
+	title     int
+	duration  int
+	checkHead bool
+}
+
+func (d *csvDecoder) Next() (*ps.SongInfo, uint64, error) {
+	for {
+		record, err := d.r.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, uint64(parseErr.StartLine), &rowError{uint64(parseErr.StartLine), parseErr.Err}
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		line, _ := d.r.FieldPos(0)
+		row := uint64(line)
+		if !d.checkHead {
+			d.checkHead = true
+			if d.readHeader(record) {
+				continue
+			}
+		}
+		if len(record) <= d.title || len(record) <= d.duration {
+			return nil, row, &rowError{row, errors.New("not enough columns")}
+		}
+		duration, errDuration := strconv.ParseUint(strings.TrimSpace(record[d.duration]), 10, 64)
+		if errDuration != nil {
+			return nil, row, &rowError{row, fmt.Errorf("invalid duration %q", record[d.duration])}
+		}
+		return &ps.SongInfo{Title: strings.TrimSpace(record[d.title]), Duration: duration}, row, nil
+	}
+}
+
+func (d *csvDecoder) readHeader(record []string) bool {
+	title, duration := -1, -1
+	for i, name := range record {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "title":
+			title = i
+		case "duration":
+			duration = i
+		}
+	}
+	if title < 0 || duration < 0 {
+		return false
+	}
+	d.title, d.duration = title, duration
+	return true
+}
+
+type ndjsonDecoder struct {
+	sc   *bufio.Scanner
+	line uint64
+}
+
+func (d *ndjsonDecoder) Next() (*ps.SongInfo, uint64, error) {
+	for d.sc.Scan() {
+		d.line++
+		text := strings.TrimSpace(d.sc.Text())
+		if text == "" {
+			continue
+		}
+		var song ps.SongInfo
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal([]byte(text), &song); err != nil {
+			return nil, d.line, &rowError{d.line, err}
+		}
+		song.Id = ""
+		clearFile(&song)
+		song.Title = strings.TrimSpace(song.Title)
+		return &song, d.line, nil
+	}
+	if err := d.sc.Err(); err != nil {
+		return nil, d.line, err
+	}
+	return nil, d.line, io.EOF
+}
+
+func songKey(info *ps.SongInfo) string {
+	return info.GetTitle() + "\x00" + strconv.FormatUint(info.GetDuration(), 10)
+}
+
+func (s *PlaylistService) ImportSongs(stream ps.PlaylistService_ImportSongsServer) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return stream.SendAndClose(&ps.ImportSongsResponse{})
+	}
+	if err != nil {
+		return err
+	}
+	pr, pw := io.Pipe()
+	defer pr.Close()
+	decoder, err := newSongDecoder(first.GetFormat(), pr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if _, errWrite := pw.Write(first.GetData()); errWrite != nil {
+			return
+		}
+		for {
+			req, errRecv := stream.Recv()
+			if errors.Is(errRecv, io.EOF) {
+				pw.Close()
+				return
+			}
+			if errRecv != nil {
+				pw.CloseWithError(errRecv)
+				return
+			}
+			if _, errWrite := pw.Write(req.GetData()); errWrite != nil {
+				return
+			}
+		}
+	}()
+	report, err := s.importSongs(stream.Context(), decoder, first.GetDryRun())
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(report)
+}
+
+func (s *PlaylistService) importSongs(ctx context.Context, decoder songDecoder, dryRun bool) (*ps.ImportSongsResponse, error) {
+	var existing []*ps.SongInfo
+	if err := s.DB.WithContext(ctx).Select("title", "duration").Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	known := make(map[string]struct{}, len(existing))
+	for _, song := range existing {
+		known[songKey(song)] = struct{}{}
+	}
+	report := &ps.ImportSongsResponse{DryRun: dryRun}
+	batch := make([]*ps.SongInfo, 0, importBatchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if !dryRun {
+			errTx := s.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+				return tx.Create(&batch).Error
+			})
+			if errTx != nil {
+				return fmt.Errorf("import error: %w", errTx)
+			}
+			_ = tracing.Do(ctx, "playlist.AddSongs", func(context.Context) error {
+				players{s}.AddSongs(batch)
+				return nil
+			})
+			for _, info := range batch {
+				s.publishLibraryChange(library.ActionAdded, info)
+			}
+		}
+		report.Created += uint64(len(batch))
+		batch = make([]*ps.SongInfo, 0, importBatchSize)
+		return nil
+	}
+	for {
+		song, row, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var errRow *rowError
+		if errors.As(err, &errRow) {
+			report.Total++
+			report.Invalid++
+			report.Errors = append(report.Errors, &ps.ImportRowError{Row: errRow.row, Error: errRow.err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		report.Total++
+		if errValidate := validateSong(song); errValidate != nil {
+			report.Invalid++
+			report.Errors = append(report.Errors, &ps.ImportRowError{Row: row, Error: errValidate.Error()})
+			continue
+		}
+		key := songKey(song)
+		if _, ok := known[key]; ok {
+			report.Duplicates++
+			continue
+		}
+		known[key] = struct{}{}
+		song.Id = uuid.New().String()
+		batch = append(batch, song)
+		if len(batch) == importBatchSize {
+			if err = flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
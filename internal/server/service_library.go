@@ -0,0 +1,35 @@
+package server
+
+import (
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/library"
+	"google.golang.org/protobuf/proto"
+)
+
+func (s *PlaylistService) ScanLibrary(_ *ps.ScanLibraryRequest, stream ps.PlaylistService_ScanLibraryServer) error {
+	total, err := s.Scanner.Scan(stream.Context(), func(progress *ps.ScanProgress) error {
+		return stream.Send(progress)
+	})
+	if err != nil {
+		return err
+	}
+	return stream.Send(total)
+}
+
+func (s *PlaylistService) publishLibraryChange(action string, song *ps.SongInfo) {
+	var event string
+	switch action {
+	case library.ActionAdded:
+		event = EventSongAdded
+	case library.ActionUpdated:
+		event = EventSongUpdated
+	case library.ActionRemoved:
+		event = EventSongRemoved
+		if s.Party != nil {
+			s.Party.Remove(song.Id)
+		}
+	default:
+		return
+	}
+	s.broadcast(&ps.PlayerInfo{Event: event, Song: proto.Clone(song).(*ps.SongInfo)})
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/party"
+)
+
+// EnableParty turns party mode on: when a song of the shared player ends, the
+// top-voted suggestion of q plays next, and every change of q is published
+// as EventPartyChanged to all Player streams.
+func (s *PlaylistService) EnableParty(q *party.Queue) {
+	q.OnChange = func(entries []party.Entry) {
+		s.broadcast(&ps.PlayerInfo{Event: EventPartyChanged, PartyQueue: partyEntries(entries)})
+	}
+	s.Party = q
+	s.P.SetPicker(q.Pop)
+}
+
+// SuggestSong puts a song of the playlist into the party queue. Suggestions,
+// like votes, are rate limited per user.
+func (s *PlaylistService) SuggestSong(ctx context.Context, req *ps.SuggestSongRequest) (*ps.PartyQueue, error) {
+	if s.Party == nil {
+		return nil, party.ErrDisabled
+	}
+	var song *ps.SongInfo
+	for _, info := range s.P.Songs() {
+		if info.Id == req.GetId() {
+			song = info
+			break
+		}
+	}
+	if song == nil {
+		return nil, playlist.ErrSongNotFound
+	}
+	user := partyUser(ctx)
+	if err := s.Party.Suggest(user, song); err != nil {
+		return nil, err
+	}
+	return &ps.PartyQueue{Entries: partyEntries(s.Party.Entries(user))}, nil
+}
+
+// VoteSong sets the vote of the caller for a suggestion: 1, -1 or 0 to take
+// it back.
+func (s *PlaylistService) VoteSong(ctx context.Context, req *ps.VoteSongRequest) (*ps.PartyQueue, error) {
+	if s.Party == nil {
+		return nil, party.ErrDisabled
+	}
+	user := partyUser(ctx)
+	if err := s.Party.Vote(user, req.GetId(), int(req.GetVote())); err != nil {
+		return nil, err
+	}
+	return &ps.PartyQueue{Entries: partyEntries(s.Party.Entries(user))}, nil
+}
+
+// GetPartyQueue returns the suggestions in the order they will play, with the
+// votes of the caller.
+func (s *PlaylistService) GetPartyQueue(ctx context.Context, _ *ps.GetPartyQueueRequest) (*ps.PartyQueue, error) {
+	if s.Party == nil {
+		return nil, party.ErrDisabled
+	}
+	return &ps.PartyQueue{Entries: partyEntries(s.Party.Entries(partyUser(ctx)))}, nil
+}
+
+// partyUser returns the subject of the caller. Without authentication all
+// callers vote as the same anonymous user.
+func partyUser(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok {
+		return principal.Subject
+	}
+	return ""
+}
+
+func partyEntries(entries []party.Entry) []*ps.PartyEntry {
+	result := make([]*ps.PartyEntry, len(entries))
+	for i, e := range entries {
+		result[i] = &ps.PartyEntry{Song: e.Song, SuggestedBy: e.SuggestedBy, Score: int64(e.Score), Vote: int32(e.Vote)}
+	}
+	return result
+}
@@ -4,33 +4,119 @@ import (
 	"context"
 	ps "github.com/sgoldenf/playlist/api"
 	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"github.com/sgoldenf/playlist/internal/tracing"
+	"golang.org/x/exp/slog"
 )
 
 func (s *PlaylistService) Init() {
 	res, err := s.GetSongs(context.Background(), &ps.ReadSongsRequest{})
 	if err != nil {
+		slog.Error("failed to load songs", "err", err)
 		s.P = playlist.NewPlaylist([]*ps.SongInfo{})
 	} else {
-		s.P = playlist.NewPlaylist(res.Songs)
+		songs := make([]*ps.SongInfo, 0, len(res.Songs))
+		for _, song := range res.Songs {
+			if !song.Missing {
+				songs = append(songs, song)
+			}
+		}
+		state := s.loadState()
+		if state != nil {
+			sortByQueue(songs, state.Queue)
+		}
+		s.P = playlist.NewPlaylist(songs)
+		if state != nil && state.SongID != "" {
+			if err = restore(s.P, state.SongID, state.Elapsed); err != nil {
+				slog.Warn("failed to restore player state", "song", state.SongID, "err", err)
+			} else {
+				_, elapsed, _ := s.P.Current()
+				slog.Info("restored player state", "song", state.SongID, "elapsed", elapsed)
+			}
+		}
+		slog.Info("playlist loaded", "songs", len(songs))
+		s.loaded = true
 	}
 }
 
-func (s *PlaylistService) Play(_ context.Context, _ *ps.PlayRequest) (*ps.PlayResponse, error) {
-	s.P.Play()
+func (s *PlaylistService) Play(ctx context.Context, _ *ps.PlayRequest) (*ps.PlayResponse, error) {
+	ss := s.session(ctx)
+	_ = tracing.Do(ctx, "playlist.Play", func(context.Context) error {
+		ss.P.Play()
+		return nil
+	})
 	return &ps.PlayResponse{Success: true}, nil
 }
 
-func (s *PlaylistService) Pause(_ context.Context, _ *ps.PauseRequest) (*ps.PauseResponse, error) {
-	s.P.Pause()
+func (s *PlaylistService) Pause(ctx context.Context, _ *ps.PauseRequest) (*ps.PauseResponse, error) {
+	ss := s.session(ctx)
+	_ = tracing.Do(ctx, "playlist.Pause", func(context.Context) error {
+		ss.P.Pause()
+		return nil
+	})
 	return &ps.PauseResponse{Success: true}, nil
 }
 
-func (s *PlaylistService) Next(_ context.Context, _ *ps.NextSongRequest) (*ps.NextSongResponse, error) {
-	s.P.Next()
+func (s *PlaylistService) Next(ctx context.Context, _ *ps.NextSongRequest) (*ps.NextSongResponse, error) {
+	ss := s.session(ctx)
+	_ = tracing.Do(ctx, "playlist.Next", func(context.Context) error {
+		ss.P.Next()
+		return nil
+	})
 	return &ps.NextSongResponse{Success: true}, nil
 }
 
-func (s *PlaylistService) Prev(_ context.Context, _ *ps.PrevSongRequest) (*ps.PrevSongResponse, error) {
-	s.P.Prev()
+func (s *PlaylistService) Prev(ctx context.Context, _ *ps.PrevSongRequest) (*ps.PrevSongResponse, error) {
+	ss := s.session(ctx)
+	_ = tracing.Do(ctx, "playlist.Prev", func(context.Context) error {
+		ss.P.Prev()
+		return nil
+	})
 	return &ps.PrevSongResponse{Success: true}, nil
 }
+
+func (s *PlaylistService) Seek(ctx context.Context, req *ps.SeekRequest) (*ps.SeekResponse, error) {
+	ss := s.session(ctx)
+	err := tracing.Do(ctx, "playlist.Seek", func(context.Context) error {
+		return ss.P.Seek(req.GetPosition())
+	})
+	if err != nil {
+		return &ps.SeekResponse{Success: false}, err
+	}
+	if song, elapsed, _ := ss.P.Current(); song != nil {
+		ss.events.publish(&ps.PlayerInfo{Title: song.Title, Duration: song.Duration, Elapsed: elapsed, Event: EventSeeked, Song: song})
+	}
+	return &ps.SeekResponse{Success: true}, nil
+}
+
+// GetPlayerState returns the current song together with the id of the last
+// published event, so a client can follow up with Player without missing
+// anything in between.
+func (s *PlaylistService) GetPlayerState(ctx context.Context, _ *ps.GetPlayerStateRequest) (*ps.PlayerState, error) {
+	ss := s.session(ctx)
+	lastID := ss.events.lastEventID()
+	song, elapsed, playing := ss.P.Current()
+	return &ps.PlayerState{Song: song, Elapsed: elapsed, Playing: playing, LastEventId: lastID}, nil
+}
+
+// GetPlaylist returns the songs in playback order, unlike GetSongs which lists
+// the library as it is stored.
+func (s *PlaylistService) GetPlaylist(ctx context.Context, _ *ps.GetPlaylistRequest) (*ps.GetPlaylistResponse, error) {
+	return &ps.GetPlaylistResponse{Songs: s.Playlist(ctx).Songs()}, nil
+}
+
+func (s *PlaylistService) MoveSong(ctx context.Context, req *ps.MoveSongRequest) (*ps.MoveSongResponse, error) {
+	ss := s.session(ctx)
+	err := tracing.Do(ctx, "playlist.Move", func(context.Context) error {
+		return ss.P.Move(req.GetId(), int(req.GetPosition()))
+	})
+	if err != nil {
+		return &ps.MoveSongResponse{Success: false}, err
+	}
+	for _, song := range ss.P.Songs() {
+		if song.Id == req.GetId() {
+			ss.events.publish(&ps.PlayerInfo{Event: EventSongMoved, Song: song})
+			break
+		}
+	}
+	return &ps.MoveSongResponse{Success: true}, nil
+}
@@ -1,33 +1,95 @@
 package server
 
 import (
+	"context"
+	"errors"
 	ps "github.com/sgoldenf/playlist/api"
-	"log"
+	"github.com/sgoldenf/playlist/internal/hls"
+	"github.com/sgoldenf/playlist/internal/logging"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
 	"time"
 )
 
-func (s *PlaylistService) Player(_ *ps.ConnectRequest, stream ps.PlaylistService_PlayerServer) error {
+var ErrNoAudioFile = errors.New("stream error: song has no audio file")
+
+func (s *PlaylistService) Player(req *ps.ConnectRequest, stream ps.PlaylistService_PlayerServer) error {
 	timer := time.NewTicker(1 * time.Second)
+	logger := logging.FromContext(stream.Context())
+	s.players.Add(1)
+	defer s.players.Add(-1)
+	ss := s.session(stream.Context())
+	ss.streams.Add(1)
+	defer ss.streams.Add(-1)
+	defer ss.touch()
+	events, missed := ss.events.subscribe(req.GetLastEventId())
+	defer ss.events.unsubscribe(events)
+	for _, info := range missed {
+		if err := stream.Send(info); err != nil {
+			return err
+		}
+	}
 	for {
 		select {
 		case <-stream.Context().Done():
 			return nil
+		case <-ss.events.done:
+			// The final event is in the channel already, but select may
+			// have picked this case first.
+			for {
+				select {
+				case info := <-events:
+					if err := stream.Send(info); err != nil {
+						return err
+					}
+				default:
+					return nil
+				}
+			}
+		case info := <-events:
+			if err := stream.Send(info); err != nil {
+				logger.Warn("failed to send player event", "event", info.Event, "err", err)
+			}
 		case <-timer.C:
-			if s.P.IsPlaying {
-				info := s.getPlayerInfo()
+			if info, playing := getPlayerInfo(ss.P); playing {
 				err := stream.Send(info)
 				if err != nil {
-					log.Println(err.Error())
+					logger.Warn("failed to send player event", "err", err)
 				}
 			}
 		}
 	}
 }
 
-func (s *PlaylistService) getPlayerInfo() *ps.PlayerInfo {
+// PlayerStreams returns the number of connected Player streams.
+func (s *PlaylistService) PlayerStreams() int {
+	return int(s.players.Load())
+}
+
+func getPlayerInfo(p *playlist.Playlist) (*ps.PlayerInfo, bool) {
+	song, elapsed, playing := p.Current()
+	if song == nil {
+		return nil, false
+	}
 	return &ps.PlayerInfo{
-		Title:    s.P.Cur.Info.Title,
-		Duration: s.P.Cur.Info.Duration,
-		Elapsed:  s.P.Cur.ElapsedTime,
+		Title:    song.Title,
+		Duration: song.Duration,
+		Elapsed:  elapsed,
+	}, playing
+}
+
+func (s *PlaylistService) GetStreamURL(ctx context.Context, req *ps.GetStreamURLRequest) (*ps.GetStreamURLResponse, error) {
+	res, err := s.GetSong(ctx, &ps.ReadSongRequest{Id: req.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	if res.Song.Path == "" || res.Song.Missing {
+		return nil, ErrNoAudioFile
+	}
+	ttl := time.Duration(req.GetTtl()) * time.Second
+	url, expires := s.Streams.URL(res.Song.Id, ttl)
+	response := &ps.GetStreamURLResponse{Url: url, Expires: expires.Unix()}
+	if hls.Supported(res.Song.Path) {
+		response.HlsUrl, _ = hls.SongURL(s.Streams, res.Song.Id, ttl)
 	}
+	return response, nil
 }
@@ -0,0 +1,321 @@
+package server
+
+import (
+	"context"
+	ps "github.com/sgoldenf/playlist/api"
+	db "github.com/sgoldenf/playlist/db"
+	"github.com/sgoldenf/playlist/internal/auth"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"golang.org/x/exp/slog"
+	"google.golang.org/protobuf/proto"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultSessionIdleTimeout = 30 * time.Minute
+	sessionSaveTimeout        = 5 * time.Second
+)
+
+// session is a player: a playlist with its own current song, position and
+// order, and the broker of its Player streams.
+type session struct {
+	user   string
+	P      *playlist.Playlist
+	events *broker
+	// used is the time of the last call in nanoseconds since the epoch.
+	used atomic.Int64
+	// streams counts the Player streams and subscriptions following the
+	// session, it is not evicted while there are any.
+	streams atomic.Int64
+}
+
+func (ss *session) touch() {
+	ss.used.Store(time.Now().UnixNano())
+}
+
+// Sessions gives every authenticated user a player of their own. A session
+// is opened on the first call of a user and evicted when it had no calls and
+// no Player streams for IdleTimeout. Its state is saved in the users table
+// then, so the next session continues where the last one stopped.
+type Sessions struct {
+	IdleTimeout time.Duration
+	m           sync.Mutex
+	users       map[string]*session
+	// changes counts the library changes applied to the sessions, a session
+	// opened while it grew catches up with the shared playlist.
+	changes uint64
+}
+
+func NewSessions(idleTimeout time.Duration) *Sessions {
+	return &Sessions{IdleTimeout: idleTimeout, users: make(map[string]*session)}
+}
+
+// Len returns the number of open sessions, 0 when sessions are disabled.
+func (s *Sessions) Len() int {
+	if s == nil {
+		return 0
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	return len(s.users)
+}
+
+// each calls f for every open session. f must not call back into s.
+func (s *Sessions) each(f func(ss *session)) {
+	if s == nil {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, ss := range s.users {
+		f(ss)
+	}
+}
+
+// change calls f for every open session to apply a library change.
+func (s *Sessions) change(f func(ss *session)) {
+	if s == nil {
+		return
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.changes++
+	for _, ss := range s.users {
+		f(ss)
+	}
+}
+
+// idle removes and returns the sessions that were idle for longer than
+// IdleTimeout at now.
+func (s *Sessions) idle(now time.Time) []*session {
+	s.m.Lock()
+	defer s.m.Unlock()
+	var evicted []*session
+	for user, ss := range s.users {
+		if ss.streams.Load() == 0 && now.Sub(time.Unix(0, ss.used.Load())) > s.IdleTimeout {
+			delete(s.users, user)
+			evicted = append(evicted, ss)
+		}
+	}
+	return evicted
+}
+
+// session returns the player of the user in ctx. Anonymous callers, and every
+// caller when sessions are disabled or the service is shutting down, share
+// the player of the service.
+func (s *PlaylistService) session(ctx context.Context) *session {
+	principal, ok := auth.FromContext(ctx)
+	if s.Sessions == nil || !ok {
+		return &session{P: s.P, events: s.events}
+	}
+	select {
+	case <-s.events.done:
+		return &session{P: s.P, events: s.events}
+	default:
+	}
+	s.Sessions.m.Lock()
+	ss, ok := s.Sessions.users[principal.Subject]
+	changes := s.Sessions.changes
+	s.Sessions.m.Unlock()
+	if ok {
+		ss.touch()
+		return ss
+	}
+	// The user is loaded without the lock, so that other calls do not wait
+	// for the database. A session opened by a concurrent call wins.
+	opened := s.openSession(ctx, principal.Subject)
+	s.Sessions.m.Lock()
+	defer s.Sessions.m.Unlock()
+	if ss, ok = s.Sessions.users[principal.Subject]; !ok {
+		ss = opened
+		if s.Sessions.changes != changes {
+			s.catchUp(ss)
+		}
+		s.Sessions.users[principal.Subject] = ss
+		go watchPlayer(ss.P, ss.events)
+	}
+	ss.touch()
+	return ss
+}
+
+// catchUp applies the library changes that the shared playlist got while ss
+// was opened.
+func (s *PlaylistService) catchUp(ss *session) {
+	songs := s.P.Songs()
+	ids := make(map[string]bool, len(songs))
+	for _, info := range songs {
+		ids[info.Id] = true
+		if !ss.P.UpdateSong(info) {
+			ss.P.AddSong(info)
+		}
+	}
+	for _, info := range ss.P.Songs() {
+		if !ids[info.Id] {
+			ss.P.DeleteSong(info.Id)
+		}
+	}
+}
+
+// Playlist returns the playlist of the caller in ctx.
+func (s *PlaylistService) Playlist(ctx context.Context) *playlist.Playlist {
+	return s.session(ctx).P
+}
+
+// ActiveSessions returns the number of open sessions.
+func (s *PlaylistService) ActiveSessions() int {
+	return s.Sessions.Len()
+}
+
+// openSession builds the player of user from the songs of the shared one and
+// the state saved in the users table. The user is created when it is new.
+func (s *PlaylistService) openSession(ctx context.Context, user string) *session {
+	songs := s.P.Songs()
+	account := db.User{ID: user}
+	now := time.Now()
+	err := s.DB.WithContext(ctx).Where(db.User{ID: user}).Attrs(db.User{CreatedAt: now, LastSeen: now}).FirstOrCreate(&account).Error
+	if err != nil {
+		slog.Warn("failed to load user", "user", user, "err", err)
+	}
+	if account.Queue != "" {
+		sortByQueue(songs, account.Queue)
+	}
+	ss := &session{user: user, P: playlist.NewPlaylist(songs), events: newBroker()}
+	if account.SongID != "" {
+		if err = restore(ss.P, account.SongID, account.Elapsed); err != nil {
+			slog.Warn("failed to restore player state", "user", user, "song", account.SongID, "err", err)
+		}
+	}
+	slog.Info("session opened", "user", user)
+	return ss
+}
+
+// saveSession stores the player of ss in the users table.
+func (s *PlaylistService) saveSession(ctx context.Context, ss *session) error {
+	account := db.User{LastSeen: time.Unix(0, ss.used.Load())}
+	account.SongID, account.Elapsed, account.Queue = playerState(ss.P)
+	return s.DB.WithContext(ctx).Model(&db.User{ID: ss.user}).
+		Select("last_seen", "song_id", "elapsed", "queue").Updates(&account).Error
+}
+
+// closeSession ends the Player streams of ss and pauses it.
+func closeSession(ss *session, info *ps.PlayerInfo) {
+	ss.events.close(info)
+	ss.P.Pause()
+}
+
+// EvictIdleSessions saves, closes and removes the sessions that were idle for
+// longer than the idle timeout.
+func (s *PlaylistService) EvictIdleSessions(ctx context.Context) {
+	if s.Sessions == nil {
+		return
+	}
+	for _, ss := range s.Sessions.idle(time.Now()) {
+		closeSession(ss, &ps.PlayerInfo{Event: EventShutdown, Title: "session expired"})
+		saveCtx, cancel := context.WithTimeout(ctx, sessionSaveTimeout)
+		if err := s.saveSession(saveCtx, ss); err != nil {
+			slog.Warn("failed to save session", "user", ss.user, "err", err)
+		}
+		cancel()
+		slog.Info("session evicted", "user", ss.user)
+	}
+}
+
+// WatchSessions evicts idle sessions every interval until ctx is done.
+func (s *PlaylistService) WatchSessions(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.EvictIdleSessions(ctx)
+		}
+	}
+}
+
+// players applies library changes to the shared playlist and to the
+// playlist of every session.
+type players struct {
+	s *PlaylistService
+}
+
+func (pl players) each(f func(p *playlist.Playlist)) {
+	f(pl.s.P)
+	pl.s.Sessions.change(func(ss *session) {
+		f(ss.P)
+	})
+}
+
+// playing reports whether the shared player or the player of a session is
+// playing the song with the given id.
+func (pl players) playing(id string) bool {
+	if pl.s.P.PlayingSong(id) {
+		return true
+	}
+	playing := false
+	pl.s.Sessions.each(func(ss *session) {
+		playing = playing || ss.P.PlayingSong(id)
+	})
+	return playing
+}
+
+// AddSong updates songs that are in a playlist already, a session opened
+// between the change of the library and this call has them.
+func (pl players) AddSong(info *ps.SongInfo) {
+	pl.each(func(p *playlist.Playlist) {
+		if !p.UpdateSong(info) {
+			p.AddSong(info)
+		}
+	})
+}
+
+func (pl players) AddSongs(infos []*ps.SongInfo) {
+	for _, info := range infos {
+		pl.AddSong(info)
+	}
+}
+
+func (pl players) UpdateSong(info *ps.SongInfo) bool {
+	found := false
+	pl.each(func(p *playlist.Playlist) {
+		found = p.UpdateSong(info) || found
+	})
+	return found
+}
+
+func (pl players) DeleteSong(id string) {
+	pl.each(func(p *playlist.Playlist) {
+		p.DeleteSong(id)
+	})
+}
+
+// DeleteSongs removes the songs with the given ids from every playlist and
+// returns those that a player kept because it is playing them, along with a
+// function that puts the removed songs restore accepts back in place.
+func (pl players) DeleteSongs(ids []string) (map[string]bool, func(restore func(id string) bool)) {
+	kept := make(map[string]bool)
+	var removals []*playlist.Removal
+	pl.each(func(p *playlist.Playlist) {
+		id, removal := p.DeleteSongs(ids)
+		if id != "" {
+			kept[id] = true
+		}
+		removals = append(removals, removal)
+	})
+	return kept, func(restore func(id string) bool) {
+		for _, removal := range removals {
+			removal.Undo(restore)
+		}
+	}
+}
+
+// broadcast publishes a copy of info to the shared player and every session.
+func (s *PlaylistService) broadcast(info *ps.PlayerInfo) {
+	s.Sessions.each(func(ss *session) {
+		ss.events.publish(proto.Clone(info).(*ps.PlayerInfo))
+	})
+	s.events.publish(info)
+}
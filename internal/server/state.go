@@ -0,0 +1,112 @@
+package server
+
+import (
+	"context"
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	db "github.com/sgoldenf/playlist/db"
+	"github.com/sgoldenf/playlist/internal/model/playlist"
+	"golang.org/x/exp/slog"
+	"google.golang.org/protobuf/proto"
+	"sort"
+	"strings"
+)
+
+const stateID = 1
+
+// Shutdown sends a final EventShutdown to the Player streams, ends them and
+// pauses the playlists of the service and of the sessions. Calls after the
+// first one only pause.
+func (s *PlaylistService) Shutdown() {
+	info := &ps.PlayerInfo{Event: EventShutdown, Title: "server shutting down"}
+	s.Sessions.each(func(ss *session) {
+		closeSession(ss, proto.Clone(info).(*ps.PlayerInfo))
+	})
+	closeSession(&session{P: s.P, events: s.events}, info)
+}
+
+// Close shuts the service down and closes the database connections.
+func (s *PlaylistService) Close() error {
+	s.Shutdown()
+	sqlDB, err := s.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
+
+// SaveState stores the current song, its position and the playlist order of
+// the shared player and of every session, so that the next start resumes where
+// this one stopped.
+func (s *PlaylistService) SaveState(ctx context.Context) error {
+	var err error
+	s.Sessions.each(func(ss *session) {
+		if errSave := s.saveSession(ctx, ss); errSave != nil && err == nil {
+			err = errSave
+		}
+	})
+	state := db.PlayerState{ID: stateID}
+	state.SongID, state.Elapsed, state.Queue = playerState(s.P)
+	if errSave := s.DB.WithContext(ctx).Save(&state).Error; errSave != nil {
+		return errSave
+	}
+	return err
+}
+
+// playerState returns the current song of p, its position and the song ids in
+// playlist order, separated by commas.
+func playerState(p *playlist.Playlist) (string, uint64, string) {
+	var songID string
+	var elapsed uint64
+	if song, position, _ := p.Current(); song != nil {
+		songID, elapsed = song.Id, position
+	}
+	songs := p.Songs()
+	ids := make([]string, len(songs))
+	for i, song := range songs {
+		ids[i] = song.Id
+	}
+	return songID, elapsed, strings.Join(ids, ",")
+}
+
+// restore makes the saved song current, from its beginning when the saved
+// position no longer fits.
+func restore(p *playlist.Playlist, songID string, elapsed uint64) error {
+	err := p.Restore(songID, elapsed)
+	if errors.Is(err, playlist.ErrSeekOutOfRange) {
+		err = p.Restore(songID, 0)
+	}
+	return err
+}
+
+// loadState returns the state saved by SaveState, or nil when there is none.
+func (s *PlaylistService) loadState() *db.PlayerState {
+	var state db.PlayerState
+	res := s.DB.Where("id = ?", stateID).Limit(1).Find(&state)
+	if res.Error != nil {
+		slog.Warn("failed to load player state", "err", res.Error)
+		return nil
+	}
+	if res.RowsAffected == 0 {
+		return nil
+	}
+	return &state
+}
+
+// sortByQueue puts songs in the order of a saved queue. Songs that were added
+// since keep their database order after the saved ones.
+func sortByQueue(songs []*ps.SongInfo, queue string) {
+	order := make(map[string]int)
+	for i, id := range strings.Split(queue, ",") {
+		order[id] = i
+	}
+	rank := func(song *ps.SongInfo) int {
+		if i, ok := order[song.Id]; ok {
+			return i
+		}
+		return len(order)
+	}
+	sort.SliceStable(songs, func(i, j int) bool {
+		return rank(songs[i]) < rank(songs[j])
+	})
+}
@@ -0,0 +1,92 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrNoFile         = errors.New("song has no audio file")
+	ErrOutsideLibrary = errors.New("song file is outside the music directories")
+)
+
+var contentTypes = map[string]string{
+	".mp3":  "audio/mpeg",
+	".flac": "audio/flac",
+	".ogg":  "audio/ogg",
+	".oga":  "audio/ogg",
+	".opus": "audio/ogg; codecs=opus",
+	".wav":  "audio/wav",
+	".aac":  "audio/aac",
+}
+
+func ContentType(path string) string {
+	ext := strings.ToLower(filepath.Ext(path))
+	if t, ok := contentTypes[ext]; ok {
+		return t
+	}
+	if t := mime.TypeByExtension(ext); t != "" {
+		return t
+	}
+	return "application/octet-stream"
+}
+
+// ServeSong writes the audio file of song if contains accepts its path. Range,
+// If-Range, If-None-Match and If-Modified-Since requests are answered by
+// http.ServeContent.
+func ServeSong(w http.ResponseWriter, r *http.Request, song *ps.SongInfo, contains func(path string) bool) {
+	if song.GetPath() == "" {
+		http.Error(w, ErrNoFile.Error(), http.StatusNotFound)
+		return
+	}
+	if contains == nil || !contains(song.Path) {
+		http.Error(w, ErrOutsideLibrary.Error(), http.StatusForbidden)
+		return
+	}
+	f, err := os.Open(song.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		http.Error(w, ErrNoFile.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer f.Close()
+	stat, err := f.Stat()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentType(song.Path))
+	w.Header().Set("ETag", fmt.Sprintf(`"%x-%x"`, stat.Size(), stat.ModTime().UnixNano()))
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	http.ServeContent(w, r, "", stat.ModTime(), f)
+}
+
+// Handler serves GET PathPrefix+":id" after checking the URL signature. Only
+// files accepted by contains are served.
+func Handler(signer *Signer, lookup func(ctx context.Context, id string) (*ps.SongInfo, error), contains func(path string) bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		if err := signer.Verify(id, c.Query("expires"), c.Query("sig")); err != nil {
+			c.String(http.StatusForbidden, err.Error())
+			return
+		}
+		song, err := lookup(c.Request.Context(), id)
+		if err != nil {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		ServeSong(c.Writer, c.Request, song, contains)
+	}
+}
@@ -0,0 +1,86 @@
+// Package stream serves audio files of songs over HTTP behind short-lived
+// signed URLs.
+package stream
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTTL = 5 * time.Minute
+	MaxTTL     = 24 * time.Hour
+	PathPrefix = "/v1/stream/"
+)
+
+var (
+	ErrExpired      = errors.New("stream url expired")
+	ErrBadSignature = errors.New("invalid stream url signature")
+)
+
+type Signer struct {
+	Secret  []byte
+	BaseURL string
+	now     func() time.Time
+}
+
+func NewSigner(secret []byte, baseURL string) *Signer {
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			panic(err)
+		}
+	}
+	return &Signer{Secret: secret, BaseURL: strings.TrimSuffix(baseURL, "/"), now: time.Now}
+}
+
+func (s *Signer) sign(id string, expires int64) string {
+	mac := hmac.New(sha256.New, s.Secret)
+	mac.Write([]byte(id))
+	mac.Write([]byte{0})
+	mac.Write([]byte(strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Query returns the expires and sig query parameters that authorize access to
+// id for ttl.
+func (s *Signer) Query(id string, ttl time.Duration) (url.Values, time.Time) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if ttl > MaxTTL {
+		ttl = MaxTTL
+	}
+	expires := s.now().Add(ttl).Truncate(time.Second)
+	query := url.Values{
+		"expires": {strconv.FormatInt(expires.Unix(), 10)},
+		"sig":     {s.sign(id, expires.Unix())},
+	}
+	return query, expires
+}
+
+func (s *Signer) URL(id string, ttl time.Duration) (string, time.Time) {
+	query, expires := s.Query(id, ttl)
+	return s.BaseURL + PathPrefix + url.PathEscape(id) + "?" + query.Encode(), expires
+}
+
+func (s *Signer) Verify(id, expires, sig string) error {
+	unix, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return ErrBadSignature
+	}
+	if !hmac.Equal([]byte(sig), []byte(s.sign(id, unix))) {
+		return ErrBadSignature
+	}
+	if s.now().Unix() > unix {
+		return ErrExpired
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	ps "github.com/sgoldenf/playlist/api"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSigner_URL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	s := NewSigner([]byte("secret"), "http://music.local/")
+	s.now = func() time.Time { return now }
+	link, expires := s.URL("song/1", time.Minute)
+	if expires.Unix() != now.Unix()+60 {
+		t.Errorf("expires is %v, expected %v", expires, now.Add(time.Minute))
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatalf("invalid url %q: %v", link, err)
+	}
+	if u.Host != "music.local" || u.EscapedPath() != "/v1/stream/song%2F1" {
+		t.Errorf("unexpected url %q", link)
+	}
+	q := u.Query()
+	if err = s.Verify("song/1", q.Get("expires"), q.Get("sig")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err = s.Verify("song/2", q.Get("expires"), q.Get("sig")); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("expected ErrBadSignature for other song, got %v", err)
+	}
+	if err = s.Verify("song/1", "1700009999", q.Get("sig")); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("expected ErrBadSignature for changed expiry, got %v", err)
+	}
+	if err = NewSigner([]byte("other"), "").Verify("song/1", q.Get("expires"), q.Get("sig")); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("expected ErrBadSignature for other secret, got %v", err)
+	}
+	now = now.Add(2 * time.Minute)
+	if err = s.Verify("song/1", q.Get("expires"), q.Get("sig")); !errors.Is(err, ErrExpired) {
+		t.Errorf("expected ErrExpired, got %v", err)
+	}
+	if _, expires = s.URL("song/1", 48*time.Hour); expires.Sub(now) != MaxTTL {
+		t.Errorf("ttl is %v, expected %v", expires.Sub(now), MaxTTL)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "song.mp3")
+	content := []byte("0123456789abcdefghij")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	songs := map[string]*ps.SongInfo{
+		"song":    {Id: "song", Path: path},
+		"nofile":  {Id: "nofile"},
+		"missing": {Id: "missing", Path: filepath.Join(dir, "missing.mp3")},
+		"outside": {Id: "outside", Path: "/etc/passwd"},
+	}
+	signer := NewSigner(nil, "")
+	r := gin.New()
+	r.GET(PathPrefix+":id", Handler(signer, func(_ context.Context, id string) (*ps.SongInfo, error) {
+		if song, ok := songs[id]; ok {
+			return song, nil
+		}
+		return nil, errors.New("song not found")
+	}, func(path string) bool {
+		return filepath.Dir(path) == dir
+	}))
+	get := func(id string, header http.Header) *http.Response {
+		link, _ := signer.URL(id, time.Minute)
+		req := httptest.NewRequest(http.MethodGet, link, nil)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w.Result()
+	}
+
+	res := get("song", nil)
+	body, _ := io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK || string(body) != string(content) ||
+		res.Header.Get("Content-Type") != "audio/mpeg" || res.Header.Get("Accept-Ranges") != "bytes" {
+		t.Errorf("full response -> %d %v %q", res.StatusCode, res.Header, body)
+	}
+	etag := res.Header.Get("ETag")
+
+	res = get("song", http.Header{"Range": {"bytes=5-9"}})
+	body, _ = io.ReadAll(res.Body)
+	if res.StatusCode != http.StatusPartialContent || string(body) != "56789" ||
+		res.Header.Get("Content-Range") != "bytes 5-9/20" {
+		t.Errorf("range response -> %d %v %q", res.StatusCode, res.Header, body)
+	}
+
+	if res = get("song", http.Header{"If-None-Match": {etag}}); res.StatusCode != http.StatusNotModified {
+		t.Errorf("expected 304 for matching ETag, got %d", res.StatusCode)
+	}
+	if res = get("song", http.Header{"Range": {"bytes=100-"}}); res.StatusCode != http.StatusRequestedRangeNotSatisfiable {
+		t.Errorf("expected 416, got %d", res.StatusCode)
+	}
+	for _, id := range []string{"nofile", "missing", "unknown"} {
+		if res = get(id, nil); res.StatusCode != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", id, res.StatusCode)
+		}
+	}
+	if res = get("outside", nil); res.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403 for a file outside the library, got %d", res.StatusCode)
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, PathPrefix+"song?expires=9999999999&sig=forged", nil))
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for forged signature, got %d", w.Code)
+	}
+}
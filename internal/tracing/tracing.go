@@ -0,0 +1,79 @@
+// Package tracing sets up OpenTelemetry tracing of the server. The server
+// code only uses the global tracer provider, which does nothing until Setup
+// or InstallLocal replaces it.
+package tracing
+
+import (
+	"context"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+)
+
+// instrumentation names the tracers of the server.
+const instrumentation = "github.com/sgoldenf/playlist"
+
+// ServiceName identifies the server in the collector.
+const ServiceName = "playlist"
+
+type Config struct {
+	// Endpoint is the host:port of an OTLP/gRPC collector.
+	Endpoint string
+	// Insecure disables TLS to the collector.
+	Insecure bool
+	// SampleRatio is the share of traces started here that are recorded,
+	// traces continued from a caller follow the caller's decision.
+	SampleRatio float64
+}
+
+// Setup installs a global tracer provider exporting to the collector of cfg.
+// The returned function flushes the buffered spans and stops the exporter.
+func Setup(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(ServiceName))),
+	)
+	install(provider)
+	return provider.Shutdown, nil
+}
+
+// InstallLocal installs a global tracer provider that records every span in
+// memory, so that tests can inspect them.
+func InstallLocal() *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	install(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	return exporter
+}
+
+func install(provider *sdktrace.TracerProvider) {
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// Do runs fn in a span named name and records the error it returns. The
+// server wraps playlist mutations in it, so that waiting for the playlist
+// lock shows up next to the database queries.
+func Do(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := otel.Tracer(instrumentation).Start(ctx, name)
+	defer span.End()
+	err := fn(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
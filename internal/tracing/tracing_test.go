@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	ps "github.com/sgoldenf/playlist/api"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"testing"
+)
+
+func spanNamed(spans tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+	return nil
+}
+
+func TestDo(t *testing.T) {
+	exporter := InstallLocal()
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	errMove := errors.New("move error: position is out of range")
+	err := Do(ctx, "playlist.Move", func(ctx context.Context) error {
+		return Do(ctx, "playlist.Inner", func(context.Context) error { return nil })
+	})
+	if err != nil {
+		t.Errorf("Do() = %v", err)
+	}
+	if err = Do(ctx, "playlist.Seek", func(context.Context) error { return errMove }); err != errMove {
+		t.Errorf("Do() = %v, want %v", err, errMove)
+	}
+	parent.End()
+
+	spans := exporter.GetSpans()
+	move, inner, seek := spanNamed(spans, "playlist.Move"), spanNamed(spans, "playlist.Inner"), spanNamed(spans, "playlist.Seek")
+	if move == nil || inner == nil || seek == nil {
+		t.Fatalf("missing spans in %v", spans)
+	}
+	if move.Parent.SpanID() != parent.SpanContext().SpanID() || inner.Parent.SpanID() != move.SpanContext.SpanID() {
+		t.Errorf("unexpected parents: %v, %v", move.Parent, inner.Parent)
+	}
+	if move.Status.Code != codes.Unset || seek.Status.Code != codes.Error || seek.Status.Description != errMove.Error() {
+		t.Errorf("unexpected statuses: %v, %v", move.Status, seek.Status)
+	}
+}
+
+func TestGORM(t *testing.T) {
+	exporter := InstallLocal()
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		Logger:               logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = db.Use(GORM()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent")
+	var songs []ps.SongInfo
+	db.WithContext(ctx).Where("title = ?", "So What").Find(&songs)
+	parent.End()
+
+	query := spanNamed(exporter.GetSpans(), "db.query")
+	if query == nil {
+		t.Fatalf("no db.query span in %v", exporter.GetSpans())
+	}
+	if query.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("db.query is not a child of the parent span")
+	}
+	attributes := make(map[string]string)
+	for _, kv := range query.Attributes {
+		attributes[string(kv.Key)] = kv.Value.Emit()
+	}
+	tests := []struct {
+		key, want string
+	}{
+		{"db.system", "postgresql"},
+		{"db.operation", "query"},
+		{"db.sql.table", "song_infos"},
+		{"db.statement", `SELECT * FROM "song_infos" WHERE title = $1`},
+	}
+	for _, test := range tests {
+		if got := attributes[test.key]; got != test.want {
+			t.Errorf("%s -> \nWant: %q\nGot : %q", test.key, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"context"
+	"github.com/gdamore/tcell/v2"
+	ps "github.com/sgoldenf/playlist/api"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"strings"
+)
+
+const help = "space play/pause  < > prev/next  ←/→ seek  d delete  J/K move  / search  q quit"
+
+func (a *App) handleKey(ev *tcell.EventKey) {
+	switch {
+	case a.searching:
+		a.searchKey(ev)
+		return
+	case a.confirm != nil:
+		if ev.Key() == tcell.KeyRune && (ev.Rune() == 'y' || ev.Rune() == 'Y') {
+			a.remove(a.confirm)
+		} else {
+			a.status = ""
+		}
+		a.confirm = nil
+		return
+	}
+	a.status = ""
+	switch ev.Key() {
+	case tcell.KeyCtrlC:
+		a.quit = true
+	case tcell.KeyUp:
+		a.moveCursor(a.cursor - 1)
+	case tcell.KeyDown:
+		a.moveCursor(a.cursor + 1)
+	case tcell.KeyPgUp:
+		a.moveCursor(a.cursor - a.listHeight())
+	case tcell.KeyPgDn:
+		a.moveCursor(a.cursor + a.listHeight())
+	case tcell.KeyHome:
+		a.moveCursor(0)
+	case tcell.KeyEnd:
+		a.moveCursor(len(a.songs) - 1)
+	case tcell.KeyLeft:
+		a.seek(-seekStep)
+	case tcell.KeyRight:
+		a.seek(seekStep)
+	case tcell.KeyDelete:
+		a.askRemove()
+	case tcell.KeyRune:
+		a.runeKey(ev.Rune())
+	}
+}
+
+func (a *App) runeKey(r rune) {
+	switch r {
+	case 'q':
+		a.quit = true
+	case 'k':
+		a.moveCursor(a.cursor - 1)
+	case 'j':
+		a.moveCursor(a.cursor + 1)
+	case 'g':
+		a.moveCursor(0)
+	case 'G':
+		a.moveCursor(len(a.songs) - 1)
+	case ' ', 'p':
+		a.toggle()
+	case '>':
+		a.call("next", func(ctx context.Context) error {
+			_, err := a.client.Next(ctx, &ps.NextSongRequest{})
+			return err
+		}, nil)
+	case '<':
+		a.call("prev", func(ctx context.Context) error {
+			_, err := a.client.Prev(ctx, &ps.PrevSongRequest{})
+			return err
+		}, nil)
+	case 'f':
+		a.seek(seekStep)
+	case 'b':
+		a.seek(-seekStep)
+	case 'd':
+		a.askRemove()
+	case 'J':
+		a.move(1)
+	case 'K':
+		a.move(-1)
+	case '/':
+		a.searching, a.query, a.searchFrom = true, "", a.cursor
+	case 'n':
+		a.findNext(a.cursor+1, 1)
+	case 'N':
+		a.findNext(a.cursor-1, -1)
+	case 'r':
+		a.reload()
+	case '?':
+		a.status = help
+	}
+}
+
+func (a *App) moveCursor(i int) {
+	if i >= len(a.songs) {
+		i = len(a.songs) - 1
+	}
+	if i < 0 {
+		i = 0
+	}
+	a.cursor = i
+}
+
+func (a *App) selected() *ps.SongInfo {
+	if a.cursor < len(a.songs) {
+		return a.songs[a.cursor]
+	}
+	return nil
+}
+
+func (a *App) toggle() {
+	if a.playing {
+		a.call("pause", func(ctx context.Context) error {
+			_, err := a.client.Pause(ctx, &ps.PauseRequest{})
+			return err
+		}, nil)
+		return
+	}
+	a.call("play", func(ctx context.Context) error {
+		_, err := a.client.Play(ctx, &ps.PlayRequest{})
+		return err
+	}, nil)
+}
+
+func (a *App) seek(delta int64) {
+	if a.length == 0 {
+		return
+	}
+	position := int64(a.elapsed) + delta
+	if position < 0 {
+		position = 0
+	}
+	if position >= int64(a.length) {
+		position = int64(a.length) - 1
+	}
+	a.call("seek", func(ctx context.Context) error {
+		_, err := a.client.Seek(ctx, &ps.SeekRequest{Position: uint64(position)})
+		return err
+	}, nil)
+}
+
+func (a *App) askRemove() {
+	if song := a.selected(); song != nil {
+		a.confirm = song
+		a.status = "delete " + cli.SongTitle(song) + "? (y/n)"
+	}
+}
+
+func (a *App) remove(song *ps.SongInfo) {
+	a.call("delete", func(ctx context.Context) error {
+		_, err := a.client.DeleteSong(ctx, &ps.DeleteSongRequest{Id: song.Id})
+		return err
+	}, func() {
+		a.status = "deleted " + cli.SongTitle(song)
+		a.reload()
+	})
+}
+
+// move shifts the selected song by delta places. The list is updated at once
+// and reloaded when the server reports the move.
+func (a *App) move(delta int) {
+	song, to := a.selected(), a.cursor+delta
+	if song == nil || to < 0 || to >= len(a.songs) {
+		return
+	}
+	a.songs[a.cursor], a.songs[to] = a.songs[to], a.songs[a.cursor]
+	a.cursor = to
+	a.call("move", func(ctx context.Context) error {
+		_, err := a.client.MoveSong(ctx, &ps.MoveSongRequest{Id: song.Id, Position: uint64(to)})
+		return err
+	}, nil)
+}
+
+func (a *App) searchKey(ev *tcell.EventKey) {
+	switch ev.Key() {
+	case tcell.KeyEscape, tcell.KeyCtrlC:
+		a.searching = false
+		a.cursor = a.searchFrom
+		return
+	case tcell.KeyEnter:
+		a.searching = false
+		return
+	case tcell.KeyBackspace, tcell.KeyBackspace2:
+		if a.query == "" {
+			return
+		}
+		runes := []rune(a.query)
+		a.query = string(runes[:len(runes)-1])
+	case tcell.KeyRune:
+		a.query += string(ev.Rune())
+	default:
+		return
+	}
+	// Every keystroke searches again from where the search started, so
+	// that deleting a character can move the cursor back.
+	a.cursor = a.searchFrom
+	a.findNext(a.searchFrom, 1)
+}
+
+// findNext moves the cursor to the first song matching the query, going from
+// start in the direction of step and wrapping around.
+func (a *App) findNext(start, step int) {
+	if a.query == "" || len(a.songs) == 0 {
+		return
+	}
+	for i := 0; i < len(a.songs); i++ {
+		j := ((start+i*step)%len(a.songs) + len(a.songs)) % len(a.songs)
+		if matches(a.songs[j], a.query) {
+			a.cursor = j
+			return
+		}
+	}
+	a.status = "no match for " + a.query
+}
+
+func matches(song *ps.SongInfo, query string) bool {
+	query = strings.ToLower(query)
+	for _, field := range []string{song.Title, song.Artist, song.Album} {
+		if strings.Contains(strings.ToLower(field), query) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	ps "github.com/sgoldenf/playlist/api"
+	"io"
+	"time"
+)
+
+// Events of the Player stream, see internal/server/events.go.
+const (
+	eventTrackChanged = "track_changed"
+	eventPlaying      = "playing"
+	eventPaused       = "paused"
+	eventSeeked       = "seeked"
+	eventSongAdded    = "song_added"
+	eventSongUpdated  = "song_updated"
+	eventSongRemoved  = "song_removed"
+	eventSongMoved    = "song_moved"
+	eventShutdown     = "shutdown"
+)
+
+// follow reads the Player stream until ctx is cancelled, reconnecting with
+// the id of the last seen event so that nothing is lost in between.
+func (a *App) follow(ctx context.Context) {
+	lastEvent := a.lastEvent
+	for ctx.Err() == nil {
+		stream, err := a.client.Player(ctx, &ps.ConnectRequest{LastEventId: lastEvent})
+		if err == nil {
+			a.post(func() { a.connected = true })
+			for {
+				info, errRecv := stream.Recv()
+				if errRecv != nil {
+					err = errRecv
+					break
+				}
+				if info.EventId != 0 {
+					lastEvent = info.EventId
+				}
+				a.post(func() { a.apply(info) })
+			}
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		a.post(func() {
+			a.connected = false
+			// The server ends the stream after a shutdown event, which
+			// already says what happened.
+			if err != io.EOF {
+				a.status = "player: " + err.Error()
+			}
+		})
+		select {
+		case <-ctx.Done():
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (a *App) apply(info *ps.PlayerInfo) {
+	switch info.Event {
+	case "":
+		a.playing = true
+		if info.Title != a.title {
+			a.current = nil
+		}
+		a.title, a.length = info.Title, info.Duration
+	case eventTrackChanged:
+		a.setCurrent(info.Song)
+	case eventPlaying:
+		a.playing = true
+	case eventPaused:
+		a.playing = false
+	case eventSeeked:
+	case eventSongAdded, eventSongUpdated, eventSongRemoved, eventSongMoved:
+		a.reload()
+		return
+	case eventShutdown:
+		a.playing = false
+		a.status = info.Title
+		return
+	default:
+		return
+	}
+	a.elapsed = info.Elapsed
+}
@@ -0,0 +1,166 @@
+// Package tui is an interactive terminal client for PlaylistService: the
+// playlist with the current song highlighted, a progress bar fed by the Player
+// stream and keys for controlling the player.
+package tui
+
+import (
+	"context"
+	"github.com/gdamore/tcell/v2"
+	ps "github.com/sgoldenf/playlist/api"
+	"time"
+)
+
+const (
+	requestTimeout = 5 * time.Second
+	reconnectDelay = 2 * time.Second
+	seekStep       = 5
+)
+
+type App struct {
+	client ps.PlaylistServiceClient
+	screen tcell.Screen
+
+	songs   []*ps.SongInfo
+	cursor  int
+	offset  int
+	current *ps.SongInfo
+	title   string
+	elapsed uint64
+	length  uint64
+	playing bool
+
+	searching  bool
+	query      string
+	searchFrom int
+	confirm    *ps.SongInfo
+	status     string
+	connected  bool
+	lastEvent  uint64
+	quit       bool
+}
+
+func New(client ps.PlaylistServiceClient, screen tcell.Screen) *App {
+	return &App{client: client, screen: screen}
+}
+
+// Run draws the UI until the user quits or ctx is cancelled. The screen must
+// not be initialised yet; Run finalises it before returning.
+func (a *App) Run(ctx context.Context) error {
+	if err := a.screen.Init(); err != nil {
+		return err
+	}
+	defer a.screen.Fini()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		a.post(func() { a.quit = true })
+	}()
+
+	a.loadState(ctx)
+	go a.follow(ctx)
+	a.draw()
+	for !a.quit {
+		switch ev := a.screen.PollEvent().(type) {
+		case nil:
+			return nil
+		case *tcell.EventResize:
+			a.screen.Sync()
+		case *tcell.EventKey:
+			a.handleKey(ev)
+		case *tcell.EventInterrupt:
+			if fn, ok := ev.Data().(func()); ok {
+				fn()
+			}
+		}
+		a.draw()
+	}
+	return nil
+}
+
+// post runs fn on the UI goroutine; everything that touches the App state
+// from another goroutine goes through it.
+func (a *App) post(fn func()) {
+	_ = a.screen.PostEvent(tcell.NewEventInterrupt(fn))
+}
+
+// call runs an RPC in the background and reports its error in the status
+// line. then, if set, runs on the UI goroutine after a successful call.
+func (a *App) call(name string, rpc func(ctx context.Context) error, then func()) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		defer cancel()
+		err := rpc(ctx)
+		a.post(func() {
+			if err != nil {
+				a.status = name + ": " + err.Error()
+				return
+			}
+			if then != nil {
+				then()
+			}
+		})
+	}()
+}
+
+// loadState fetches the playlist and the player state synchronously, so the
+// first frame already shows them.
+func (a *App) loadState(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	state, err := a.client.GetPlayerState(ctx, &ps.GetPlayerStateRequest{})
+	if err != nil {
+		a.status = "state: " + err.Error()
+	} else {
+		a.lastEvent = state.LastEventId
+		a.setCurrent(state.Song)
+		a.elapsed, a.playing = state.Elapsed, state.Playing
+	}
+	res, err := a.client.GetPlaylist(ctx, &ps.GetPlaylistRequest{})
+	if err != nil {
+		a.status = "playlist: " + err.Error()
+		return
+	}
+	a.setSongs(res.Songs)
+}
+
+func (a *App) reload() {
+	var songs []*ps.SongInfo
+	a.call("playlist", func(ctx context.Context) error {
+		res, err := a.client.GetPlaylist(ctx, &ps.GetPlaylistRequest{})
+		songs = res.GetSongs()
+		return err
+	}, func() { a.setSongs(songs) })
+}
+
+// setSongs replaces the playlist and keeps the cursor on the same song when
+// it is still there.
+func (a *App) setSongs(songs []*ps.SongInfo) {
+	var selected string
+	if a.cursor < len(a.songs) {
+		selected = a.songs[a.cursor].Id
+	}
+	a.songs = songs
+	for i, song := range songs {
+		if song.Id == selected {
+			a.cursor = i
+			return
+		}
+	}
+	a.moveCursor(0)
+}
+
+func (a *App) setCurrent(song *ps.SongInfo) {
+	a.current = song
+	a.title, a.length = "", 0
+	if song != nil {
+		a.title, a.length = song.Title, song.Duration
+	}
+}
+
+func (a *App) isCurrent(song *ps.SongInfo) bool {
+	if a.current != nil {
+		return song.Id == a.current.Id
+	}
+	return a.title != "" && song.Title == a.title
+}
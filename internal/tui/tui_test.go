@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"context"
+	"github.com/gdamore/tcell/v2"
+	ps "github.com/sgoldenf/playlist/api"
+	"google.golang.org/grpc"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeClient struct {
+	ps.PlaylistServiceClient
+	songs  []*ps.SongInfo
+	calls  chan string
+	player chan *ps.PlayerInfo
+}
+
+type fakePlayer struct {
+	grpc.ClientStream
+	ctx    context.Context
+	player chan *ps.PlayerInfo
+}
+
+func (p *fakePlayer) Recv() (*ps.PlayerInfo, error) {
+	select {
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	case info := <-p.player:
+		return info, nil
+	}
+}
+
+func (c *fakeClient) GetPlayerState(context.Context, *ps.GetPlayerStateRequest, ...grpc.CallOption) (*ps.PlayerState, error) {
+	return &ps.PlayerState{Song: c.songs[1], Elapsed: 30, LastEventId: 7}, nil
+}
+
+func (c *fakeClient) GetPlaylist(context.Context, *ps.GetPlaylistRequest, ...grpc.CallOption) (*ps.GetPlaylistResponse, error) {
+	return &ps.GetPlaylistResponse{Songs: c.songs}, nil
+}
+
+func (c *fakeClient) Player(ctx context.Context, req *ps.ConnectRequest, _ ...grpc.CallOption) (ps.PlaylistService_PlayerClient, error) {
+	c.calls <- "player " + strings.Repeat("+", int(req.LastEventId))
+	return &fakePlayer{ctx: ctx, player: c.player}, nil
+}
+
+func (c *fakeClient) Play(context.Context, *ps.PlayRequest, ...grpc.CallOption) (*ps.PlayResponse, error) {
+	c.calls <- "play"
+	return &ps.PlayResponse{Success: true}, nil
+}
+
+func (c *fakeClient) Seek(_ context.Context, req *ps.SeekRequest, _ ...grpc.CallOption) (*ps.SeekResponse, error) {
+	c.calls <- "seek " + strings.Repeat("+", int(req.Position))
+	return &ps.SeekResponse{Success: true}, nil
+}
+
+func (c *fakeClient) MoveSong(_ context.Context, req *ps.MoveSongRequest, _ ...grpc.CallOption) (*ps.MoveSongResponse, error) {
+	c.calls <- "move " + req.Id + " " + strings.Repeat("+", int(req.Position))
+	return &ps.MoveSongResponse{Success: true}, nil
+}
+
+func (c *fakeClient) DeleteSong(_ context.Context, req *ps.DeleteSongRequest, _ ...grpc.CallOption) (*ps.DeleteSongResponse, error) {
+	c.calls <- "delete " + req.Id
+	return &ps.DeleteSongResponse{Success: true}, nil
+}
+
+func (c *fakeClient) expect(t *testing.T, want string) {
+	t.Helper()
+	select {
+	case got := <-c.calls:
+		if got != want {
+			t.Errorf("Out -> \nWant: %v\nGot : %v", want, got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %q", want)
+	}
+}
+
+// onUI runs fn on the UI goroutine and waits for it.
+func onUI(a *App, fn func()) {
+	done := make(chan struct{})
+	a.post(func() {
+		fn()
+		close(done)
+	})
+	<-done
+}
+
+// waitFor polls cond on the UI goroutine, for state that arrives through the
+// Player stream.
+func waitFor(t *testing.T, a *App, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		ok := false
+		onUI(a, func() { ok = cond() })
+		if ok {
+			return
+		}
+	}
+	t.Errorf("timed out waiting for %s", what)
+}
+
+func screenText(s tcell.SimulationScreen) string {
+	cells, w, _ := s.GetContents()
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 && i%w == 0 {
+			b.WriteByte('\n')
+		}
+		if len(cell.Runes) > 0 {
+			b.WriteRune(cell.Runes[0])
+		}
+	}
+	return b.String()
+}
+
+func TestApp(t *testing.T) {
+	client := &fakeClient{
+		songs: []*ps.SongInfo{
+			{Id: "a", Title: "Intro", Artist: "Band", Duration: 60},
+			{Id: "b", Title: "Chorus", Artist: "Band", Duration: 200},
+			{Id: "c", Title: "Outro", Artist: "Other", Duration: 90},
+		},
+		calls:  make(chan string, 16),
+		player: make(chan *ps.PlayerInfo),
+	}
+	screen := tcell.NewSimulationScreen("UTF-8")
+	app := New(client, screen)
+	done := make(chan error)
+	go func() { done <- app.Run(context.Background()) }()
+	client.expect(t, "player +++++++")
+
+	onUI(app, func() {
+		text := screenText(screen)
+		for _, want := range []string{"Intro", "⏸ Chorus", "Outro", "[paused] Band - Chorus", "00:30 [", "] 03:20"} {
+			if !strings.Contains(text, want) {
+				t.Errorf("screen does not contain %q:\n%s", want, text)
+			}
+		}
+	})
+
+	screen.InjectKey(tcell.KeyRune, ' ', tcell.ModNone)
+	client.expect(t, "play")
+	client.player <- &ps.PlayerInfo{Title: "Chorus", Duration: 200, Elapsed: 31}
+	waitFor(t, app, "the tick", func() bool {
+		return app.playing && app.elapsed == 31 && strings.Contains(screenText(screen), "▶ Chorus")
+	})
+	screen.InjectKey(tcell.KeyRight, 0, tcell.ModNone)
+	client.expect(t, "seek "+strings.Repeat("+", 36))
+
+	screen.InjectKey(tcell.KeyRune, 'J', tcell.ModNone)
+	client.expect(t, "move a +")
+	onUI(app, func() {
+		if app.cursor != 1 || app.songs[1].Id != "a" {
+			t.Errorf("moved song is not under the cursor: %d %v", app.cursor, app.songs)
+		}
+	})
+
+	for _, r := range "/out" {
+		screen.InjectKey(tcell.KeyRune, r, tcell.ModNone)
+	}
+	onUI(app, func() {
+		if !app.searching || app.cursor != 2 || app.query != "out" {
+			t.Errorf("search: searching %v, cursor %d, query %q", app.searching, app.cursor, app.query)
+		}
+	})
+	for i := 0; i < 3; i++ {
+		screen.InjectKey(tcell.KeyBackspace2, 0, tcell.ModNone)
+	}
+	screen.InjectKey(tcell.KeyRune, 'c', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'h', tcell.ModNone)
+	screen.InjectKey(tcell.KeyEnter, 0, tcell.ModNone)
+	onUI(app, func() {
+		if app.searching || app.cursor != 0 {
+			t.Errorf("search \"ch\" should wrap around to Chorus, cursor %d", app.cursor)
+		}
+	})
+
+	screen.InjectKey(tcell.KeyRune, 'd', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'n', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'd', tcell.ModNone)
+	screen.InjectKey(tcell.KeyRune, 'y', tcell.ModNone)
+	client.expect(t, "delete b")
+
+	screen.InjectKey(tcell.KeyRune, 'q', tcell.ModNone)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run did not return after q")
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		w               int
+		elapsed, length uint64
+		want            string
+	}{
+		{24, 0, 100, "00:00 [          ] 01:40"},
+		{24, 50, 100, "00:50 [====>     ] 01:40"},
+		{24, 100, 100, "01:40 [==========] 01:40"},
+		{24, 5, 0, "00:05 [          ] 00:00"},
+		{10, 5, 10, "00:05     "},
+	}
+	for _, test := range tests {
+		if got := progressBar(test.w, test.elapsed, test.length); got != test.want {
+			t.Errorf("Out -> \nWant: %q\nGot : %q", test.want, got)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+package tui
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/mattn/go-runewidth"
+	"github.com/sgoldenf/playlist/internal/cli"
+	"strings"
+)
+
+var (
+	styleDefault  = tcell.StyleDefault
+	styleHeader   = tcell.StyleDefault.Bold(true).Underline(true)
+	styleCurrent  = tcell.StyleDefault.Foreground(tcell.ColorYellow).Bold(true)
+	styleMissing  = tcell.StyleDefault.Foreground(tcell.ColorGray)
+	styleProgress = tcell.StyleDefault.Foreground(tcell.ColorGreen)
+	styleError    = tcell.StyleDefault.Foreground(tcell.ColorRed)
+)
+
+// listHeight is the number of playlist rows: the screen without the header
+// and the three lines at the bottom.
+func (a *App) listHeight() int {
+	_, h := a.screen.Size()
+	if h < 5 {
+		return 1
+	}
+	return h - 4
+}
+
+func (a *App) draw() {
+	a.screen.Clear()
+	w, h := a.screen.Size()
+	a.drawHeader(w)
+	a.drawList(w)
+	a.drawNowPlaying(w, h-3)
+	a.drawProgress(w, h-2)
+	a.drawStatus(w, h-1)
+	a.screen.Show()
+}
+
+// columns splits the width between title, artist and album; the duration
+// always takes 6 cells and the marker 2.
+func columns(w int) (title, artist, album int) {
+	rest := w - 2 - 6 - 3
+	if rest < 3 {
+		return rest, 0, 0
+	}
+	return rest / 2, rest / 4, rest - rest/2 - rest/4
+}
+
+func (a *App) drawHeader(w int) {
+	title, artist, album := columns(w)
+	line := "  " + pad("Title", title) + " " + pad("Artist", artist) + " " + pad("Album", album) + " " + pad("Time", 6)
+	drawText(a.screen, 0, 0, w, line, styleHeader)
+}
+
+func (a *App) drawList(w int) {
+	height := a.listHeight()
+	if a.cursor < a.offset {
+		a.offset = a.cursor
+	}
+	if a.cursor >= a.offset+height {
+		a.offset = a.cursor - height + 1
+	}
+	title, artist, album := columns(w)
+	for row := 0; row < height && a.offset+row < len(a.songs); row++ {
+		i := a.offset + row
+		song := a.songs[i]
+		style, marker := styleDefault, "  "
+		if song.Missing {
+			style = styleMissing
+		}
+		if a.isCurrent(song) {
+			style, marker = styleCurrent, "⏸ "
+			if a.playing {
+				marker = "▶ "
+			}
+		}
+		if i == a.cursor {
+			style = style.Reverse(true)
+		}
+		line := marker + pad(song.Title, title) + " " + pad(song.Artist, artist) + " " + pad(song.Album, album) + " " +
+			fmt.Sprintf("%6s", cli.FormatDuration(song.Duration))
+		drawText(a.screen, 0, row+1, w, pad(line, w), style)
+	}
+	if len(a.songs) == 0 {
+		drawText(a.screen, 2, 1, w-2, "The playlist is empty", styleMissing)
+	}
+}
+
+func (a *App) drawNowPlaying(w, y int) {
+	state := "stopped"
+	switch {
+	case a.playing:
+		state = "playing"
+	case a.title != "":
+		state = "paused"
+	}
+	line := "[" + state + "]"
+	if a.current != nil {
+		line += " " + cli.SongTitle(a.current)
+	} else if a.title != "" {
+		line += " " + a.title
+	}
+	if !a.connected {
+		line += " (disconnected)"
+	}
+	drawText(a.screen, 0, y, w, line, styleCurrent)
+}
+
+func (a *App) drawProgress(w, y int) {
+	drawText(a.screen, 0, y, w, progressBar(w, a.elapsed, a.length), styleProgress)
+}
+
+// progressBar renders "MM:SS [=====>    ] MM:SS" in exactly w cells.
+func progressBar(w int, elapsed, length uint64) string {
+	left, right := cli.FormatDuration(elapsed)+" [", "] "+cli.FormatDuration(length)
+	inner := w - len(left) - len(right)
+	if inner < 1 {
+		return pad(left[:len(left)-2], w)
+	}
+	done := 0
+	if length > 0 {
+		done = int(uint64(inner) * elapsed / length)
+	}
+	if done > inner {
+		done = inner
+	}
+	bar := strings.Repeat("=", done)
+	if done < inner {
+		if done > 0 {
+			bar = bar[:done-1] + ">"
+		}
+		bar += strings.Repeat(" ", inner-done)
+	}
+	return left + bar + right
+}
+
+func (a *App) drawStatus(w, y int) {
+	switch {
+	case a.searching:
+		drawText(a.screen, 0, y, w, "/"+a.query, styleDefault)
+		a.screen.ShowCursor(1+runewidth.StringWidth(a.query), y)
+		return
+	case a.status != "":
+		drawText(a.screen, 0, y, w, a.status, styleError)
+	default:
+		drawText(a.screen, 0, y, w, help, styleMissing)
+	}
+	a.screen.HideCursor()
+}
+
+// pad truncates or pads text with spaces to exactly width cells.
+func pad(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	return runewidth.FillRight(runewidth.Truncate(text, width, "…"), width)
+}
+
+func drawText(s tcell.Screen, x, y, w int, text string, style tcell.Style) {
+	end := x + w
+	for _, r := range text {
+		if x >= end {
+			return
+		}
+		s.SetContent(x, y, r, nil, style)
+		x += runewidth.RuneWidth(r)
+	}
+}
@@ -0,0 +1,377 @@
+"use strict";
+
+// uint64 fields arrive as strings in protojson.
+const num = (v) => Number(v || 0);
+
+const state = {
+  songs: [],
+  current: null,
+  title: "",
+  elapsed: 0,
+  duration: 0,
+  playing: false,
+  editing: null,
+  // party is the queue of suggestions, null when party mode is off.
+  party: null,
+};
+
+const $ = (id) => document.getElementById(id);
+
+function formatTime(seconds) {
+  seconds = Math.max(0, Math.floor(seconds));
+  const s = String(seconds % 60).padStart(2, "0");
+  return `${Math.floor(seconds / 60)}:${s}`;
+}
+
+function parseTime(text) {
+  const parts = text.trim().split(":").map(Number);
+  if (parts.some((p) => !Number.isInteger(p) || p < 0)) {
+    return NaN;
+  }
+  return parts.reduce((total, p) => total * 60 + p, 0);
+}
+
+function showError(err) {
+  const box = $("message");
+  box.textContent = err.message || String(err);
+  box.hidden = false;
+  clearTimeout(showError.timer);
+  showError.timer = setTimeout(() => (box.hidden = true), 5000);
+}
+
+// The API key or JWT is asked for when the server rejects a request and kept
+// in localStorage.
+const credentials = {
+  get token() {
+    return localStorage.getItem("playlist.token") || "";
+  },
+  ask() {
+    const token = prompt("API key or token");
+    if (token) {
+      localStorage.setItem("playlist.token", token);
+    }
+    return !!token;
+  },
+};
+
+async function api(method, path, body, retried) {
+  const headers = body ? { "Content-Type": "application/json" } : {};
+  if (credentials.token) {
+    headers.Authorization = `Bearer ${credentials.token}`;
+  }
+  const res = await fetch(path, {
+    method,
+    headers,
+    body: body ? JSON.stringify(body) : undefined,
+  });
+  if (res.status === 401 && !retried && credentials.ask()) {
+    return api(method, path, body, true);
+  }
+  const data = await res.json().catch(() => ({}));
+  if (!res.ok) {
+    throw new Error(data.message || `${method} ${path}: ${res.status}`);
+  }
+  return data;
+}
+
+async function loadSongs() {
+  try {
+    const data = await api("GET", "/v1/songs");
+    state.songs = data.songs || [];
+  } catch (err) {
+    // The service reports an empty library as an error.
+    state.songs = [];
+    if (!/not found/.test(err.message)) {
+      showError(err);
+    }
+  }
+  renderSongs();
+}
+
+function cell(text, className) {
+  const td = document.createElement("td");
+  td.textContent = text;
+  if (className) {
+    td.className = className;
+  }
+  return td;
+}
+
+function button(label, onClick) {
+  const b = document.createElement("button");
+  b.textContent = label;
+  b.addEventListener("click", onClick);
+  return b;
+}
+
+function input(name, value) {
+  const td = document.createElement("td");
+  const el = document.createElement("input");
+  el.name = name;
+  el.value = value;
+  td.appendChild(el);
+  return td;
+}
+
+function isCurrent(song) {
+  return state.current ? song.id === state.current.id : song.title === state.title;
+}
+
+function renderSongs() {
+  const body = $("songs");
+  body.replaceChildren();
+  for (const song of state.songs) {
+    const tr = document.createElement("tr");
+    tr.dataset.id = song.id;
+    if (isCurrent(song)) {
+      tr.classList.add("current");
+    }
+    if (song.missing) {
+      tr.classList.add("missing");
+    }
+    if (state.editing === song.id) {
+      tr.append(
+        input("title", song.title),
+        input("artist", song.artist || ""),
+        input("album", song.album || ""),
+        input("duration", formatTime(num(song.duration))),
+      );
+      const actions = cell("");
+      actions.append(button("Save", () => saveSong(tr, song.id)), button("Cancel", () => {
+        state.editing = null;
+        renderSongs();
+      }));
+      tr.appendChild(actions);
+    } else {
+      tr.append(
+        cell(song.title),
+        cell(song.artist || ""),
+        cell(song.album || ""),
+        cell(formatTime(num(song.duration)), "num"),
+      );
+      const actions = cell("");
+      if (state.party && !song.missing) {
+        actions.append(button("Suggest", () => suggestSong(song)));
+      }
+      actions.append(button("Edit", () => {
+        state.editing = song.id;
+        renderSongs();
+      }), button("Delete", () => deleteSong(song)));
+      tr.appendChild(actions);
+    }
+    body.appendChild(tr);
+  }
+}
+
+function readSong(row) {
+  const value = (name) => row.querySelector(`input[name=${name}]`).value.trim();
+  const duration = parseTime(value("duration"));
+  if (!value("title") || !(duration > 0)) {
+    throw new Error("title and duration (m:ss) are required");
+  }
+  return { title: value("title"), artist: value("artist"), album: value("album"), duration };
+}
+
+async function saveSong(row, id) {
+  try {
+    await api("PATCH", `/v1/songs/${encodeURIComponent(id)}`, readSong(row));
+    state.editing = null;
+    await loadSongs();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function addSong() {
+  const row = $("new-song");
+  try {
+    await api("POST", "/v1/songs", readSong(row));
+    row.querySelectorAll("input").forEach((el) => (el.value = ""));
+    await loadSongs();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function deleteSong(song) {
+  if (!confirm(`Delete "${song.title}"?`)) {
+    return;
+  }
+  try {
+    await api("DELETE", `/v1/songs/${encodeURIComponent(song.id)}`);
+    await loadSongs();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function loadParty() {
+  try {
+    const data = await api("GET", "/v1/party");
+    state.party = data.entries || [];
+  } catch (err) {
+    // Party mode is off.
+    state.party = null;
+  }
+  renderParty();
+}
+
+function renderParty() {
+  $("party").hidden = state.party === null;
+  const list = $("party-queue");
+  list.replaceChildren();
+  for (const entry of state.party || []) {
+    const li = document.createElement("li");
+    const up = button("▲", () => voteSong(entry.song, entry.vote === 1 ? 0 : 1));
+    const down = button("▼", () => voteSong(entry.song, entry.vote === -1 ? 0 : -1));
+    up.classList.toggle("voted", entry.vote === 1);
+    down.classList.toggle("voted", entry.vote === -1);
+    const score = document.createElement("span");
+    score.className = "score";
+    score.textContent = num(entry.score);
+    const title = document.createElement("span");
+    title.textContent = [entry.song.artist, entry.song.title].filter(Boolean).join(" - ");
+    const by = document.createElement("span");
+    by.className = "artist";
+    by.textContent = entry.suggestedBy ? ` suggested by ${entry.suggestedBy}` : "";
+    li.append(up, score, down, title, by);
+    list.appendChild(li);
+  }
+}
+
+async function suggestSong(song) {
+  try {
+    const data = await api("POST", "/v1/party:suggest", { id: song.id });
+    state.party = data.entries || [];
+    renderParty();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function voteSong(song, vote) {
+  try {
+    const data = await api("POST", "/v1/party:vote", { id: song.id, vote });
+    state.party = data.entries || [];
+    renderParty();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function renderPlayer() {
+  const song = state.current;
+  $("title").textContent = state.title || "Nothing is playing";
+  $("artist").textContent = song ? [song.artist, song.album].filter(Boolean).join(" — ") : "";
+  $("toggle").innerHTML = state.playing ? "&#x23F8;" : "&#x25B6;";
+  $("elapsed").textContent = formatTime(state.elapsed);
+  $("duration").textContent = formatTime(state.duration);
+  const percent = state.duration ? (100 * state.elapsed) / state.duration : 0;
+  $("fill").style.width = `${Math.min(100, percent)}%`;
+  document.title = state.title ? `${state.playing ? "▶" : "⏸"} ${state.title}` : "Playlist";
+}
+
+function setSong(song) {
+  const changed = !state.current || !song || state.current.id !== song.id;
+  state.current = song || null;
+  state.title = song ? song.title : "";
+  state.duration = song ? num(song.duration) : 0;
+  if (changed) {
+    renderSongs();
+  }
+}
+
+function onEvent(type, info) {
+  switch (type) {
+    case "song_added":
+    case "song_updated":
+    case "song_removed":
+      loadSongs();
+      return;
+    case "party_changed": {
+      // Events carry no votes of this client, keep the ones it knows.
+      const mine = new Map((state.party || []).map((e) => [e.song.id, e.vote]));
+      state.party = (info.partyQueue || []).map((e) => ({ ...e, vote: mine.get(e.song.id) || 0 }));
+      renderParty();
+      return;
+    }
+    case "tick":
+      state.playing = true;
+      state.duration = num(info.duration);
+      if (info.title !== state.title) {
+        state.current = null;
+        state.title = info.title;
+        renderSongs();
+      }
+      break;
+    case "track_changed":
+      setSong(info.song);
+      break;
+    case "playing":
+      state.playing = true;
+      break;
+    case "paused":
+      state.playing = false;
+      break;
+    case "shutdown":
+      state.playing = false;
+      showError(info.title);
+      renderPlayer();
+      return;
+  }
+  state.elapsed = num(info.elapsed);
+  renderPlayer();
+}
+
+function connect(lastEventId) {
+  let url = `/v1/player/events?last_event_id=${lastEventId}`;
+  if (credentials.token) {
+    url += `&access_token=${encodeURIComponent(credentials.token)}`;
+  }
+  const source = new EventSource(url);
+  for (const type of ["tick", "track_changed", "playing", "paused", "seeked", "song_added", "song_updated", "song_removed", "party_changed", "shutdown"]) {
+    source.addEventListener(type, (e) => onEvent(type, JSON.parse(e.data)));
+  }
+}
+
+async function command(name, body) {
+  try {
+    await api("POST", `/v1/player:${name}`, body);
+  } catch (err) {
+    showError(err);
+  }
+}
+
+async function init() {
+  $("prev").addEventListener("click", () => command("prev"));
+  $("next").addEventListener("click", () => command("next"));
+  $("toggle").addEventListener("click", async () => {
+    await command(state.playing ? "pause" : "play");
+  });
+  $("bar").addEventListener("click", async (e) => {
+    if (!state.duration) {
+      return;
+    }
+    const rect = e.currentTarget.getBoundingClientRect();
+    const position = Math.floor(((e.clientX - rect.left) / rect.width) * state.duration);
+    await command("seek", { position: Math.min(position, state.duration - 1) });
+  });
+  $("add").addEventListener("click", addSong);
+
+  let lastEventId = 0;
+  try {
+    const player = await api("GET", "/v1/player/state");
+    lastEventId = num(player.lastEventId);
+    state.playing = !!player.playing;
+    state.elapsed = num(player.elapsed);
+    setSong(player.song);
+  } catch (err) {
+    showError(err);
+  }
+  renderPlayer();
+  await loadParty();
+  await loadSongs();
+  connect(lastEventId);
+}
+
+init();
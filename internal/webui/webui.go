@@ -0,0 +1,22 @@
+// Package webui holds the static browser controller for the player. It talks
+// to the server only through the REST API and the /v1/player/events feed.
+package webui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+const PathPrefix = "/ui"
+
+//go:embed static
+var static embed.FS
+
+func FS() http.FileSystem {
+	files, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	return http.FS(files)
+}